@@ -0,0 +1,63 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/application#application-object-application-flags
+type ApplicationFlag uint64
+
+const (
+	APPLICATION_AUTO_MODERATION_RULE_CREATE_BADGE_APPLICATION_FLAG ApplicationFlag = 1 << 6  // App uses Auto Moderation API.
+	GATEWAY_PRESENCE_APPLICATION_FLAG                              ApplicationFlag = 1 << 12 // Intent required for bots in 100 or more servers to receive presence_update events.
+	GATEWAY_PRESENCE_LIMITED_APPLICATION_FLAG                      ApplicationFlag = 1 << 13 // Intent required for bots in under 100 servers to receive presence_update events.
+	GATEWAY_GUILD_MEMBERS_APPLICATION_FLAG                         ApplicationFlag = 1 << 14 // Intent required for bots in 100 or more servers to receive member-related events.
+	GATEWAY_GUILD_MEMBERS_LIMITED_APPLICATION_FLAG                 ApplicationFlag = 1 << 15 // Intent required for bots in under 100 servers to receive member-related events.
+	VERIFICATION_PENDING_GUILD_LIMIT_APPLICATION_FLAG              ApplicationFlag = 1 << 16 // Indicates unusual growth of an app that prevents verification.
+	EMBEDDED_APPLICATION_FLAG                                      ApplicationFlag = 1 << 17 // Indicates if an app is embedded within the Discord client.
+	GATEWAY_MESSAGE_CONTENT_APPLICATION_FLAG                       ApplicationFlag = 1 << 18 // Intent required for bots in 100 or more servers to receive message content.
+	GATEWAY_MESSAGE_CONTENT_LIMITED_APPLICATION_FLAG               ApplicationFlag = 1 << 19 // Intent required for bots in under 100 servers to receive message content.
+	APPLICATION_COMMAND_BADGE_APPLICATION_FLAG                     ApplicationFlag = 1 << 23 // Indicates if an app has registered global application commands.
+)
+
+// https://discord.com/developers/docs/resources/application#application-object-application-structure
+type Application struct {
+	ID                             Snowflake       `json:"id"`
+	Name                           string          `json:"name"`
+	IconHash                       string          `json:"icon,omitempty"`
+	Description                    string          `json:"description"`
+	RPCOrigins                     []string        `json:"rpc_origins,omitempty"`
+	BotPublic                      bool            `json:"bot_public"`             // When false, only the app owner can add the app to guilds.
+	BotRequireCodeGrant            bool            `json:"bot_require_code_grant"` // When true, the app's bot will only join upon completion of the full OAuth2 code grant flow.
+	Bot                            *User           `json:"bot,omitempty"`
+	TermsOfServiceURL              string          `json:"terms_of_service_url,omitempty"`
+	PrivacyPolicyURL               string          `json:"privacy_policy_url,omitempty"`
+	Owner                          *User           `json:"owner,omitempty"`
+	VerifyKey                      string          `json:"verify_key"` // Hex encoded key for verification in interactions (same as ClientOptions.PublicKey).
+	GuildID                        Snowflake       `json:"guild_id,omitempty"`
+	PrimarySKUID                   Snowflake       `json:"primary_sku_id,omitempty"`
+	Slug                           string          `json:"slug,omitempty"`
+	CoverImageHash                 string          `json:"cover_image,omitempty"`
+	Flags                          ApplicationFlag `json:"flags,omitempty"`
+	ApproximateGuildCount          uint32          `json:"approximate_guild_count,omitempty"`
+	RedirectURIs                   []string        `json:"redirect_uris,omitempty"`
+	InteractionsEndpointURL        string          `json:"interactions_endpoint_url,omitempty"`
+	RoleConnectionsVerificationURL string          `json:"role_connections_verification_url,omitempty"`
+	Tags                           []string        `json:"tags,omitempty"` // Up to 5 tags describing the content and functionality of the app.
+	CustomInstallURL               string          `json:"custom_install_url,omitempty"`
+}
+
+// Checks whether application has given flag set.
+func (app Application) HasFlag(flag ApplicationFlag) bool {
+	return app.Flags&flag == flag
+}
+
+// https://discord.com/developers/docs/resources/application#edit-current-application-json-params
+//
+// Leave fields as <nil> to keep their current values. Icon & cover image are expected as data uri (see DataURI function).
+type ApplicationParams struct {
+	CustomInstallURL               *string          `json:"custom_install_url,omitempty"`
+	Description                    *string          `json:"description,omitempty"`
+	RoleConnectionsVerificationURL *string          `json:"role_connections_verification_url,omitempty"`
+	Flags                          *ApplicationFlag `json:"flags,omitempty"` // Only limited intent flags (GATEWAY_*_LIMITED_APPLICATION_FLAG) can be updated.
+	Icon                           *string          `json:"icon,omitempty"`
+	CoverImage                     *string          `json:"cover_image,omitempty"`
+	InteractionsEndpointURL        *string          `json:"interactions_endpoint_url,omitempty"`
+	Tags                           []string         `json:"tags,omitempty"`
+}
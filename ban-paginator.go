@@ -0,0 +1,58 @@
+package tempest
+
+// Iterates over guild bans page by page (sorted by user ids), so large ban lists don't need to be loaded into memory at once.
+// Create it with Client.GuildBans function. It's not safe for concurrent use.
+//
+//	bans := client.GuildBans(guildID, 100)
+//	for bans.HasMore() {
+//		page, err := bans.Next()
+//		...
+//	}
+type BanPaginator struct {
+	client   *Client
+	guildID  Snowflake
+	pageSize uint32
+	after    Snowflake
+	finished bool
+}
+
+// Creates paginator over guild bans. Page size can be up to 1000, use 0 for max size.
+func (client *Client) GuildBans(guildID Snowflake, pageSize uint32) *BanPaginator {
+	if pageSize == 0 || pageSize > MAX_BANS_PAGE_SIZE {
+		pageSize = MAX_BANS_PAGE_SIZE
+	}
+
+	return &BanPaginator{
+		client:   client,
+		guildID:  guildID,
+		pageSize: pageSize,
+	}
+}
+
+// Whether there might be more bans to fetch. It turns false after Next returns page smaller than page size.
+func (paginator *BanPaginator) HasMore() bool {
+	return !paginator.finished
+}
+
+// Fetches next page of bans. It returns empty list (without making request) once all bans were fetched.
+// On error, cursor stays in place so calling Next again retries the same page.
+func (paginator *BanPaginator) Next() ([]Ban, error) {
+	if paginator.finished {
+		return []Ban{}, nil
+	}
+
+	page, err := paginator.client.FetchBans(paginator.guildID, paginator.pageSize, 0, paginator.after)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page) != 0 {
+		paginator.after = page[len(page)-1].User.ID
+	}
+
+	if uint32(len(page)) < paginator.pageSize {
+		paginator.finished = true
+	}
+
+	return page, nil
+}
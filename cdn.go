@@ -0,0 +1,63 @@
+package tempest
+
+import (
+	"encoding/base64"
+	"strconv"
+)
+
+// Returns direct url to user's avatar. Discord accepts sizes that are powers of 2 between 16 and 4096, other sizes are rounded to the nearest
+// accepted one (use 0 for Discord's default size). Animated avatars (hash with "a_" prefix) are returned in gif format.
+func UserAvatarURL(userID Snowflake, hash string, size int) string {
+	return DISCORD_CDN_URL + "/avatars/" + userID.String() + "/" + hash + cdnExtension(hash) + cdnSizeQuery(size)
+}
+
+// Returns direct url to guild's icon. Size is rounded the same way as in UserAvatarURL (use 0 for Discord's default size).
+// Animated icons (hash with "a_" prefix) are returned in gif format.
+func GuildIconURL(guildID Snowflake, hash string, size int) string {
+	return DISCORD_CDN_URL + "/icons/" + guildID.String() + "/" + hash + cdnExtension(hash) + cdnSizeQuery(size)
+}
+
+func EmojiURL(emojiID Snowflake, animated bool) string {
+	if animated {
+		return DISCORD_CDN_URL + "/emojis/" + emojiID.String() + ".gif"
+	}
+	return DISCORD_CDN_URL + "/emojis/" + emojiID.String() + ".png"
+}
+
+// Returns direct url to sticker in format matching its type (see Sticker.FormatType) - png (also for apng), gif or json (lottie).
+func StickerURL(stickerID Snowflake, format StickerFormatType) string {
+	extension := ".png"
+	switch format {
+	case GIF_STICKER_FORMAT_TYPE:
+		extension = ".gif"
+	case LOTTIE_STICKER_FORMAT_TYPE:
+		extension = ".json"
+	}
+	return DISCORD_CDN_URL + "/stickers/" + stickerID.String() + extension
+}
+
+// Encodes file as data uri, the format Discord expects when uploading images or sounds through JSON (like app icon).
+// Content type of images should be one of: "image/png", "image/jpeg", "image/gif" or "image/webp" and "audio/mpeg" or "audio/ogg" for sounds.
+func DataURI(contentType string, data []byte) string {
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+func cdnExtension(hash string) string {
+	if len(hash) > 2 && hash[:2] == "a_" {
+		return ".gif"
+	}
+	return ".png"
+}
+
+// Discord accepts image sizes that are powers of 2 between 16 and 4096, others are rounded to the nearest one (ties round up).
+func cdnSizeQuery(size int) string {
+	if size <= 0 {
+		return ""
+	}
+
+	accepted := 16
+	for accepted < 4096 && accepted*2-size <= size-accepted {
+		accepted *= 2
+	}
+	return "?size=" + strconv.Itoa(accepted)
+}
@@ -0,0 +1,29 @@
+package tempest
+
+import "testing"
+
+func TestCDN(t *testing.T) {
+	if url := UserAvatarURL(80351110224678912, "8342729096ea3675442027381ff50dfe", 256); url != DISCORD_CDN_URL+"/avatars/80351110224678912/8342729096ea3675442027381ff50dfe.png?size=256" {
+		t.Errorf("invalid user avatar url: %s", url)
+	}
+
+	if url := GuildIconURL(1, "a_06c16474723fe537c283b8efa61a30c8", 0); url != DISCORD_CDN_URL+"/icons/1/a_06c16474723fe537c283b8efa61a30c8.gif" {
+		t.Errorf("invalid guild icon url: %s", url)
+	}
+
+	if url := EmojiURL(2, true); url != DISCORD_CDN_URL+"/emojis/2.gif" {
+		t.Errorf("invalid emoji url: %s", url)
+	}
+
+	for format, expected := range map[StickerFormatType]string{PNG_STICKER_FORMAT_TYPE: ".png", APNG_STICKER_FORMAT_TYPE: ".png", GIF_STICKER_FORMAT_TYPE: ".gif", LOTTIE_STICKER_FORMAT_TYPE: ".json"} {
+		if url := StickerURL(3, format); url != DISCORD_CDN_URL+"/stickers/3"+expected {
+			t.Errorf("invalid sticker url for %d format: %s", format, url)
+		}
+	}
+
+	for size, expected := range map[int]string{0: "", -1: "", 8: "?size=16", 16: "?size=16", 48: "?size=64", 100: "?size=128", 4096: "?size=4096", 8192: "?size=4096"} {
+		if query := cdnSizeQuery(size); query != expected {
+			t.Errorf("expected %q for %d size, got %q", expected, size, query)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package tempest
+
+import "time"
+
+// https://discord.com/developers/docs/resources/channel#channel-object-channel-structure
+type Channel struct {
+	ID                         Snowflake             `json:"id"`
+	Type                       ChannelType           `json:"type"`
+	GuildID                    Snowflake             `json:"guild_id,omitempty"`
+	Position                   uint32                `json:"position,omitempty"` // Sorting position of the channel.
+	PermissionOverwrites       []PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	Name                       string                `json:"name,omitempty"`
+	Topic                      string                `json:"topic,omitempty"`
+	NSFW                       bool                  `json:"nsfw,omitempty"`
+	LastMessageID              Snowflake             `json:"last_message_id,omitempty"`
+	Bitrate                    uint32                `json:"bitrate,omitempty"`             // The bitrate (in bits) of the voice channel.
+	UserLimit                  uint32                `json:"user_limit,omitempty"`          // The user limit of the voice channel.
+	RateLimitPerUser           uint32                `json:"rate_limit_per_user,omitempty"` // Slowmode, amount of seconds a user has to wait before sending another message (0-21600).
+	OwnerID                    Snowflake             `json:"owner_id,omitempty"`            // Id of the creator of the group DM or thread.
+	ParentID                   Snowflake             `json:"parent_id,omitempty"`           // For guild channels: id of the parent category, for threads: id of the text channel this thread was created.
+	LastPinTimestamp           *time.Time            `json:"last_pin_timestamp,omitempty"`
+	RTCRegion                  string                `json:"rtc_region,omitempty"` // Voice region id for the voice channel, automatic when empty.
+	MessageCount               uint32                `json:"message_count,omitempty"`
+	MemberCount                uint32                `json:"member_count,omitempty"`
+	DefaultAutoArchiveDuration uint32                `json:"default_auto_archive_duration,omitempty"` // In minutes.
+	PermissionFlags            uint64                `json:"permissions,string,omitempty"`            // Computed permissions for the invoking user in the channel, including overwrites. Only included when part of the resolved data received on a slash command interaction.
+	Flags                      uint64                `json:"flags,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/channel#overwrite-object-overwrite-structure
+type PermissionOverwriteType uint8
+
+const (
+	ROLE_PERMISSION_OVERWRITE_TYPE PermissionOverwriteType = iota
+	MEMBER_PERMISSION_OVERWRITE_TYPE
+)
+
+// https://discord.com/developers/docs/resources/channel#overwrite-object
+type PermissionOverwrite struct {
+	ID    Snowflake               `json:"id"` // Role or user id.
+	Type  PermissionOverwriteType `json:"type"`
+	Allow uint64                  `json:"allow,string"` // Permission bit set.
+	Deny  uint64                  `json:"deny,string"`  // Permission bit set.
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-channel-positions-json-params
+//
+// Only ID is required, leave other fields as <nil> to keep their current values.
+type ChannelPosition struct {
+	ID              Snowflake  `json:"id"`
+	Position        *uint32    `json:"position,omitempty"`
+	LockPermissions *bool      `json:"lock_permissions,omitempty"` // Syncs the permission overwrites with the new parent, if moving to a new category.
+	ParentID        *Snowflake `json:"parent_id,omitempty"`        // The new parent id for the channel that is moved.
+}
+
+// https://discord.com/developers/docs/resources/channel#followed-channel-object
+type FollowedChannel struct {
+	ChannelID Snowflake `json:"channel_id"` // Source (announcement) channel id.
+	WebhookID Snowflake `json:"webhook_id"` // Created target webhook id.
+}
+
+// https://discord.com/developers/docs/resources/channel#start-thread-in-forum-or-media-channel-jsonform-params
+type ForumPostParams struct {
+	Name                string       `json:"name"`
+	AutoArchiveDuration uint32       `json:"auto_archive_duration,omitempty"` // In minutes, can be set to: 60, 1440, 4320 or 10080.
+	RateLimitPerUser    uint32       `json:"rate_limit_per_user,omitempty"`   // Slowmode in seconds (0-21600).
+	AppliedTags         []Snowflake  `json:"applied_tags,omitempty"`          // Ids of forum tags to apply to post.
+	Message             ForumMessage `json:"message"`                         // Contents of the first message in the forum post.
+}
+
+// https://discord.com/developers/docs/resources/channel#start-thread-in-forum-or-media-channel-forum-and-media-thread-message-params-object
+type ForumMessage struct {
+	Content         string           `json:"content,omitempty"`
+	Embeds          []*Embed         `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Components      []*ComponentRow  `json:"components,omitempty"`
+	StickerIDs      []Snowflake      `json:"sticker_ids,omitempty"`
+	Flags           uint64           `json:"flags,omitempty"`
+
+	Files []File `json:"-"` // Files uploaded together with message. It's a Tempest specific field.
+}
+
+// https://discord.com/developers/docs/resources/channel#thread-member-object
+type ThreadMember struct {
+	ID            Snowflake  `json:"id,omitempty"`      // Thread id.
+	UserID        Snowflake  `json:"user_id,omitempty"` // User id.
+	JoinTimestamp *time.Time `json:"join_timestamp"`
+	Flags         uint64     `json:"flags"`            // Any user-thread settings, currently only used for notifications.
+	Member        *Member    `json:"member,omitempty"` // Only present when fetched with "with member" option.
+}
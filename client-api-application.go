@@ -0,0 +1,131 @@
+package tempest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+// Fetches application object associated with the app's token.
+func (client *Client) FetchApplication() (Application, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/applications/@me", nil)
+	if err != nil {
+		return Application{}, err
+	}
+
+	res := Application{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Application{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Edits properties of the app associated with the app's token and returns its updated state.
+func (client *Client) EditApplication(params ApplicationParams) (Application, error) {
+	raw, _, err := client.Rest.Request(http.MethodPatch, "/applications/@me", params)
+	if err != nil {
+		return Application{}, err
+	}
+
+	res := Application{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Application{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+type testEntitlementParams struct {
+	SKUID     Snowflake            `json:"sku_id"`
+	OwnerID   Snowflake            `json:"owner_id"`
+	OwnerType EntitlementOwnerType `json:"owner_type"`
+}
+
+// Fetches entitlements (premium offerings) for current app, active and ended.
+func (client *Client) FetchEntitlements(options EntitlementOptions) ([]Entitlement, error) {
+	route := "/applications/" + client.ApplicationID.String() + "/entitlements"
+	if query := options.query(); len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Entitlement, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Creates test entitlement to given sku for guild or user (see GUILD_ENTITLEMENT_OWNER_TYPE and USER_ENTITLEMENT_OWNER_TYPE).
+// Discord will act as though that user or guild has premium access to your app.
+func (client *Client) CreateTestEntitlement(skuID Snowflake, ownerID Snowflake, ownerType EntitlementOwnerType) (Entitlement, error) {
+	raw, _, err := client.Rest.Request(http.MethodPost, "/applications/"+client.ApplicationID.String()+"/entitlements", testEntitlementParams{
+		SKUID:     skuID,
+		OwnerID:   ownerID,
+		OwnerType: ownerType,
+	})
+	if err != nil {
+		return Entitlement{}, err
+	}
+
+	res := Entitlement{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Entitlement{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Deletes currently active test entitlement. Discord will act as though that user or guild no longer has premium access to your app.
+func (client *Client) DeleteTestEntitlement(entitlementID Snowflake) error {
+	_, _, err := client.Rest.Request(http.MethodDelete, "/applications/"+client.ApplicationID.String()+"/entitlements/"+entitlementID.String(), nil)
+	return err
+}
+
+// Fetches all skus (purchasable premium offerings) of current app.
+func (client *Client) FetchSKUs() ([]SKU, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/applications/"+client.ApplicationID.String()+"/skus", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SKU, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Replaces app's role connection metadata records (up to 5). Those define requirements guilds can use for linked roles.
+func (client *Client) SetRoleConnectionMetadata(records []RoleConnectionMetadata) error {
+	if records == nil {
+		records = make([]RoleConnectionMetadata, 0)
+	}
+
+	_, _, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/role-connections/metadata", records)
+	return err
+}
+
+// Updates role connection of user that authorized your app through OAuth2 (requires "role_connections.write" scope).
+// Metadata keys should match keys of records set with Client.SetRoleConnectionMetadata.
+func (client *Client) UpdateUserRoleConnection(bearerToken string, platformName string, platformUsername string, metadata map[string]string) error {
+	_, _, err := client.Rest.RequestWithBearer(bearerToken, http.MethodPut, "/users/@me/applications/"+client.ApplicationID.String()+"/role-connection", roleConnectionParams{
+		PlatformName:     platformName,
+		PlatformUsername: platformUsername,
+		Metadata:         metadata,
+	})
+	return err
+}
@@ -0,0 +1,159 @@
+package tempest
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+type permissionOverwriteParams struct {
+	Allow uint64                  `json:"allow,string"`
+	Deny  uint64                  `json:"deny,string"`
+	Type  PermissionOverwriteType `json:"type"`
+}
+
+type followChannelParams struct {
+	WebhookChannelID Snowflake `json:"webhook_channel_id"`
+}
+
+func (client *Client) FetchChannel(channelID Snowflake) (Channel, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/channels/"+channelID.String(), nil)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	res := Channel{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Channel{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Edits the channel permission overwrites for a user or role in a channel. Permissions are bit sets (see permission flags like VIEW_CHANNEL_PERMISSION_FLAG).
+// Set overwriteType to ROLE_PERMISSION_OVERWRITE_TYPE when overwriteID is role id or MEMBER_PERMISSION_OVERWRITE_TYPE when it's user id.
+func (client *Client) SetChannelPermissions(channelID Snowflake, overwriteID Snowflake, allow uint64, deny uint64, overwriteType PermissionOverwriteType) error {
+	_, _, err := client.Rest.Request(http.MethodPut, "/channels/"+channelID.String()+"/permissions/"+overwriteID.String(), permissionOverwriteParams{
+		Allow: allow,
+		Deny:  deny,
+		Type:  overwriteType,
+	})
+	return err
+}
+
+// Deletes a channel permission overwrite for a user or role in a channel.
+func (client *Client) DeleteChannelPermissions(channelID Snowflake, overwriteID Snowflake) error {
+	_, _, err := client.Rest.Request(http.MethodDelete, "/channels/"+channelID.String()+"/permissions/"+overwriteID.String(), nil)
+	return err
+}
+
+// Follows an announcement channel to send its messages to target (webhook) channel. Requires MANAGE_WEBHOOKS permission in target channel.
+func (client *Client) FollowChannel(channelID Snowflake, webhookChannelID Snowflake) (FollowedChannel, error) {
+	raw, _, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/followers", followChannelParams{
+		WebhookChannelID: webhookChannelID,
+	})
+	if err != nil {
+		return FollowedChannel{}, err
+	}
+
+	res := FollowedChannel{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return FollowedChannel{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Creates new post (thread with first message) in forum channel. Files from params.Message are uploaded together with post.
+func (client *Client) CreateForumPost(channelID Snowflake, params ForumPostParams) (Channel, error) {
+	raw, _, err := client.Rest.RequestWithFiles(http.MethodPost, "/channels/"+channelID.String()+"/threads", params, params.Message.Files)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	res := Channel{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Channel{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches thread participants. Set withMember = true to also receive guild member objects.
+// Use after (user id) as cursor to paginate through large threads and limit (1-100) to set page size (0 for Discord's default).
+func (client *Client) FetchThreadMembers(threadID Snowflake, withMember bool, after Snowflake, limit uint32) ([]ThreadMember, error) {
+	query := url.Values{}
+	if withMember {
+		query.Set("with_member", "true")
+	}
+
+	if !after.IsZero() {
+		query.Set("after", after.String())
+	}
+
+	if limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	}
+
+	route := "/channels/" + threadID.String() + "/thread-members"
+	if len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ThreadMember, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches single thread participant (with guild member object).
+func (client *Client) FetchThreadMember(threadID Snowflake, userID Snowflake) (ThreadMember, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/channels/"+threadID.String()+"/thread-members/"+userID.String()+"?with_member=true", nil)
+	if err != nil {
+		return ThreadMember{}, err
+	}
+
+	res := ThreadMember{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return ThreadMember{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Creates new invite for channel. Requires CREATE_INSTANT_INVITE_PERMISSION_FLAG.
+// It fails with ErrConflictingInviteExpiry (without making any request) when both MaxAge and ExpiresAt are set.
+func (client *Client) CreateInvite(channelID Snowflake, params InviteParams) (Invite, error) {
+	params, err := params.resolveMaxAge()
+	if err != nil {
+		return Invite{}, err
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/invites", params)
+	if err != nil {
+		return Invite{}, err
+	}
+
+	res := Invite{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Invite{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,394 @@
+package tempest
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+type guildTemplateParams struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (client *Client) FetchGuildTemplate(code string) (GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/templates/"+code, nil)
+	if err != nil {
+		return GuildTemplate{}, err
+	}
+
+	res := GuildTemplate{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildTemplate{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+func (client *Client) FetchGuildTemplates(guildID Snowflake) ([]GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/templates", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]GuildTemplate, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Creates a template for the guild. Description is optional (leave empty string to skip it).
+func (client *Client) CreateGuildTemplate(guildID Snowflake, name string, description string) (GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodPost, "/guilds/"+guildID.String()+"/templates", guildTemplateParams{
+		Name:        name,
+		Description: description,
+	})
+	if err != nil {
+		return GuildTemplate{}, err
+	}
+
+	res := GuildTemplate{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildTemplate{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Syncs the template to the guild's current state.
+func (client *Client) SyncGuildTemplate(guildID Snowflake, code string) (GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodPut, "/guilds/"+guildID.String()+"/templates/"+code, nil)
+	if err != nil {
+		return GuildTemplate{}, err
+	}
+
+	res := GuildTemplate{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildTemplate{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Modifies the template's metadata. Leave name or description as empty string to keep their current values.
+func (client *Client) EditGuildTemplate(guildID Snowflake, code string, name string, description string) (GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodPatch, "/guilds/"+guildID.String()+"/templates/"+code, guildTemplateParams{
+		Name:        name,
+		Description: description,
+	})
+	if err != nil {
+		return GuildTemplate{}, err
+	}
+
+	res := GuildTemplate{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildTemplate{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Deletes the template and returns its last state.
+func (client *Client) DeleteGuildTemplate(guildID Snowflake, code string) (GuildTemplate, error) {
+	raw, _, err := client.Rest.Request(http.MethodDelete, "/guilds/"+guildID.String()+"/templates/"+code, nil)
+	if err != nil {
+		return GuildTemplate{}, err
+	}
+
+	res := GuildTemplate{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildTemplate{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+func (client *Client) FetchGuildWidget(guildID Snowflake) (GuildWidget, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/widget", nil)
+	if err != nil {
+		return GuildWidget{}, err
+	}
+
+	res := GuildWidget{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildWidget{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Modifies guild's widget settings. Set channelID to <nil> to remove widget channel.
+func (client *Client) EditGuildWidget(guildID Snowflake, enabled bool, channelID *Snowflake) (GuildWidget, error) {
+	raw, _, err := client.Rest.Request(http.MethodPatch, "/guilds/"+guildID.String()+"/widget", GuildWidget{
+		Enabled:   enabled,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return GuildWidget{}, err
+	}
+
+	res := GuildWidget{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildWidget{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches public widget data of the guild. Works only when guild has enabled widget.
+func (client *Client) FetchGuildWidgetJSON(guildID Snowflake) (GuildWidgetData, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/widget.json", nil)
+	if err != nil {
+		return GuildWidgetData{}, err
+	}
+
+	res := GuildWidgetData{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildWidgetData{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches all guild channels. It doesn't include threads.
+func (client *Client) FetchGuildChannels(guildID Snowflake) ([]Channel, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/channels", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Channel, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches all guild roles (including @everyone role, which shares id with guild).
+func (client *Client) FetchRoles(guildID Snowflake) ([]Role, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/roles", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Role, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Modifies the positions of a set of channels in the guild. Only channels to be modified are required.
+func (client *Client) ModifyGuildChannelPositions(guildID Snowflake, positions []ChannelPosition) error {
+	_, _, err := client.Rest.Request(http.MethodPatch, "/guilds/"+guildID.String()+"/channels", positions)
+	return err
+}
+
+// Returns list of voice regions for the guild. Unlike Client.FetchVoiceRegions, this returns VIP servers when the guild is VIP-enabled.
+func (client *Client) FetchGuildVoiceRegions(guildID Snowflake) ([]VoiceRegion, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/regions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]VoiceRegion, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+func (client *Client) FetchGuildOnboarding(guildID Snowflake) (GuildOnboarding, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/onboarding", nil)
+	if err != nil {
+		return GuildOnboarding{}, err
+	}
+
+	res := GuildOnboarding{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildOnboarding{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Modifies the onboarding configuration of the guild. Requires MANAGE_GUILD and MANAGE_ROLES permissions.
+func (client *Client) EditGuildOnboarding(guildID Snowflake, params GuildOnboardingParams) (GuildOnboarding, error) {
+	raw, _, err := client.Rest.Request(http.MethodPut, "/guilds/"+guildID.String()+"/onboarding", params)
+	if err != nil {
+		return GuildOnboarding{}, err
+	}
+
+	res := GuildOnboarding{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GuildOnboarding{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches list of guild's soundboard sounds. Sounds include user field only when app can manage guild expressions.
+func (client *Client) FetchSoundboardSounds(guildID Snowflake) ([]SoundboardSound, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/soundboard-sounds", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := soundboardSoundList{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res.Items, nil
+}
+
+// Creates new soundboard sound in guild. Requires permission to create guild expressions.
+func (client *Client) CreateSoundboardSound(guildID Snowflake, params SoundboardSoundParams) (SoundboardSound, error) {
+	raw, _, err := client.Rest.Request(http.MethodPost, "/guilds/"+guildID.String()+"/soundboard-sounds", params)
+	if err != nil {
+		return SoundboardSound{}, err
+	}
+
+	res := SoundboardSound{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return SoundboardSound{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Edits guild's soundboard sound. Requires MANAGE_GUILD_EXPRESSIONS_PERMISSION_FLAG (unless sound was created by app).
+func (client *Client) EditSoundboardSound(guildID Snowflake, soundID Snowflake, params SoundboardSoundParams) (SoundboardSound, error) {
+	params.Sound = ""
+	raw, _, err := client.Rest.Request(http.MethodPatch, "/guilds/"+guildID.String()+"/soundboard-sounds/"+soundID.String(), params)
+	if err != nil {
+		return SoundboardSound{}, err
+	}
+
+	res := SoundboardSound{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return SoundboardSound{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Deletes guild's soundboard sound. Requires MANAGE_GUILD_EXPRESSIONS_PERMISSION_FLAG (unless sound was created by app).
+func (client *Client) DeleteSoundboardSound(guildID Snowflake, soundID Snowflake) error {
+	_, _, err := client.Rest.Request(http.MethodDelete, "/guilds/"+guildID.String()+"/soundboard-sounds/"+soundID.String(), nil)
+	return err
+}
+
+// Fetches users subscribed to guild scheduled event. Limit (1-100) sets page size (0 for Discord's default) and withMember = true includes guild member objects.
+// Use before or after (user ids) as cursors to paginate through large lists, leave them as 0 to skip.
+func (client *Client) FetchScheduledEventUsers(guildID Snowflake, eventID Snowflake, limit uint32, withMember bool, before Snowflake, after Snowflake) ([]ScheduledEventUser, error) {
+	query := url.Values{}
+	if limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	}
+
+	if withMember {
+		query.Set("with_member", "true")
+	}
+
+	if !before.IsZero() {
+		query.Set("before", before.String())
+	}
+
+	if !after.IsZero() {
+		query.Set("after", after.String())
+	}
+
+	route := "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String() + "/users"
+	if len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ScheduledEventUser, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches guild bans (together with their reasons). Limit (1-1000) sets page size (0 for Discord's default of 1000).
+// Use before or after (user ids) as cursors to paginate through large lists, leave them as 0 to skip.
+func (client *Client) FetchBans(guildID Snowflake, limit uint32, before Snowflake, after Snowflake) ([]Ban, error) {
+	query := url.Values{}
+	if limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	}
+
+	if !before.IsZero() {
+		query.Set("before", before.String())
+	}
+
+	if !after.IsZero() {
+		query.Set("after", after.String())
+	}
+
+	route := "/guilds/" + guildID.String() + "/bans"
+	if len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Ban, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+func (client *Client) FetchBan(guildID Snowflake, userID Snowflake) (Ban, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/bans/"+userID.String(), nil)
+	if err != nil {
+		return Ban{}, err
+	}
+
+	res := Ban{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Ban{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
@@ -3,6 +3,8 @@ package tempest
 import (
 	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/sugawarayuuta/sonnet"
@@ -15,8 +17,16 @@ func (client *Client) Ping() time.Duration {
 	return time.Since(start)
 }
 
+// Sends message to target channel. It'll return ErrContentTooLong or ErrEmbedTooLong without making any request when message exceeds Discord's limits.
+//
+// Message flags are passed as they are, valid values are: 0 (none), SUPPRESS_EMBEDS_MESSAGE_FLAG (no link previews),
+// SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG (silent message) or SUPPRESS_EMBEDS_MESSAGE_FLAG | SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG.
 func (client *Client) SendMessage(channelID Snowflake, content Message) (Message, error) {
-	raw, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/messages", content)
+	if err := content.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/messages", content)
 	if err != nil {
 		return Message{}, err
 	}
@@ -30,8 +40,18 @@ func (client *Client) SendMessage(channelID Snowflake, content Message) (Message
 	return res, nil
 }
 
-func (client *Client) SendLinearMessage(channelID Snowflake, content string) (Message, error) {
-	raw, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/messages", Message{Content: content})
+// Sends message together with uploaded files. Files are checked with ValidateFiles (using ClientOptions.MaxUploadSize) before making request.
+// Reference them in embeds with "attachment://<file name>" urls.
+func (client *Client) SendMessageWithFiles(channelID Snowflake, content Message, files []File) (Message, error) {
+	if err := content.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	if err := ValidateFiles(files, client.maxUploadSize); err != nil {
+		return Message{}, err
+	}
+
+	raw, _, err := client.Rest.RequestWithFiles(http.MethodPost, "/channels/"+channelID.String()+"/messages", content, files)
 	if err != nil {
 		return Message{}, err
 	}
@@ -45,13 +65,21 @@ func (client *Client) SendLinearMessage(channelID Snowflake, content string) (Me
 	return res, nil
 }
 
+func (client *Client) SendLinearMessage(channelID Snowflake, content string) (Message, error) {
+	return client.SendMessage(channelID, Message{Content: content})
+}
+
 // Creates (or fetches if already exists) user's private text channel (DM) and tries to send message into it.
 // Warning! Discord's user channels endpoint has huge rate limits so please reuse Message#ChannelID whenever possible.
 func (client *Client) SendPrivateMessage(userID Snowflake, content Message) (Message, error) {
+	if err := content.Validate(); err != nil {
+		return Message{}, err
+	}
+
 	res := make(map[string]interface{}, 0)
 	res["recipient_id"] = userID
 
-	raw, err := client.Rest.Request(http.MethodPost, "/users/@me/channels", res)
+	raw, _, err := client.Rest.Request(http.MethodPost, "/users/@me/channels", res)
 	if err != nil {
 		return Message{}, err
 	}
@@ -73,22 +101,28 @@ func (client *Client) SendPrivateMessage(userID Snowflake, content Message) (Mes
 }
 
 func (client *Client) EditMessage(channelID Snowflake, messageID Snowflake, content Message) error {
-	_, err := client.Rest.Request(http.MethodPatch, "/channels/"+channelID.String()+"/messages"+messageID.String(), content)
+	_, _, err := client.Rest.Request(http.MethodPatch, "/channels/"+channelID.String()+"/messages/"+messageID.String(), content)
 	return err
 }
 
 func (client *Client) DeleteMessage(channelID Snowflake, messageID Snowflake) error {
-	_, err := client.Rest.Request(http.MethodDelete, "/channels/"+channelID.String()+"/messages"+messageID.String(), nil)
+	_, _, err := client.Rest.Request(http.MethodDelete, "/channels/"+channelID.String()+"/messages/"+messageID.String(), nil)
 	return err
 }
 
 func (client *Client) CrosspostMessage(channelID Snowflake, messageID Snowflake) error {
-	_, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/messages"+messageID.String()+"/crosspost", nil)
+	_, _, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/messages/"+messageID.String()+"/crosspost", nil)
+	return err
+}
+
+// Immediately ends poll attached to message. It only works on polls created by app itself.
+func (client *Client) ExpirePoll(channelID Snowflake, messageID Snowflake) error {
+	_, _, err := client.Rest.Request(http.MethodPost, "/channels/"+channelID.String()+"/polls/"+messageID.String()+"/expire", nil)
 	return err
 }
 
 func (client *Client) FetchUser(id Snowflake) (User, error) {
-	raw, err := client.Rest.Request(http.MethodGet, "/users/"+id.String(), nil)
+	raw, _, err := client.Rest.Request(http.MethodGet, "/users/"+id.String(), nil)
 	if err != nil {
 		return User{}, err
 	}
@@ -103,7 +137,7 @@ func (client *Client) FetchUser(id Snowflake) (User, error) {
 }
 
 func (client *Client) FetchMember(guildID Snowflake, memberID Snowflake) (Member, error) {
-	raw, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/members/"+memberID.String(), nil)
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String()+"/members/"+memberID.String(), nil)
 	if err != nil {
 		return Member{}, err
 	}
@@ -116,3 +150,183 @@ func (client *Client) FetchMember(guildID Snowflake, memberID Snowflake) (Member
 
 	return res, nil
 }
+
+// Modifies attributes of guild member and returns its updated state. Only fields set in params are changed.
+func (client *Client) EditMember(guildID Snowflake, userID Snowflake, params MemberParams) (Member, error) {
+	raw, _, err := client.Rest.Request(http.MethodPatch, "/guilds/"+guildID.String()+"/members/"+userID.String(), params)
+	if err != nil {
+		return Member{}, err
+	}
+
+	res := Member{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Member{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches page of guild members sorted by their user ids. Limit (1-1000) sets page size (0 for Discord's default of 1).
+// Use after (user id) as cursor to paginate through guild, leave it as 0 to start from the beginning.
+// Warning! It requires privileged GUILD_MEMBERS intent to be enabled for your app.
+func (client *Client) FetchMembers(guildID Snowflake, limit uint32, after Snowflake) ([]Member, error) {
+	query := url.Values{}
+	if limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	}
+
+	if !after.IsZero() {
+		query.Set("after", after.String())
+	}
+
+	route := "/guilds/" + guildID.String() + "/members"
+	if len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Member, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Pages through all guild members (using FetchMembers) and returns ones that have selected role, sorted by their user ids.
+// Use limit to stop after collecting that many members (0 for no limit). Keep in mind that it makes one request per
+// 1000 guild members so it might take a while on large guilds.
+func (client *Client) FilterMembersByRole(guildID Snowflake, roleID Snowflake, limit uint) ([]Member, error) {
+	res := make([]Member, 0)
+	var after Snowflake
+
+	for {
+		page, err := client.FetchMembers(guildID, MAX_MEMBERS_PAGE_SIZE, after)
+		if err != nil {
+			return nil, err
+		}
+
+		previous := after
+		for _, member := range page {
+			if member.User != nil {
+				after = member.User.ID
+			}
+
+			if !member.HasRole(roleID) {
+				continue
+			}
+
+			res = append(res, member)
+			if limit != 0 && uint(len(res)) >= limit {
+				return res, nil
+			}
+		}
+
+		if len(page) < MAX_MEMBERS_PAGE_SIZE || after == previous {
+			return res, nil
+		}
+	}
+}
+
+// Returns list of voice regions that can be used when setting a voice or stage channel's rtc region.
+func (client *Client) FetchVoiceRegions() ([]VoiceRegion, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/voice/regions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]VoiceRegion, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches list of Discord's built-in (standard) sticker packs.
+func (client *Client) FetchStickerPacks() ([]StickerPack, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/sticker-packs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := stickerPackList{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res.StickerPacks, nil
+}
+
+// Fetches gateway url with recommended shard count & session start limits.
+// Tempest itself doesn't use gateway but it's useful for bots that run separate gateway client alongside.
+func (client *Client) FetchGatewayBotInfo() (GatewayBotInfo, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/gateway/bot", nil)
+	if err != nil {
+		return GatewayBotInfo{}, err
+	}
+
+	res := GatewayBotInfo{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return GatewayBotInfo{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches app's own bot user. Its id matches ApplicationID only for newer apps, older ones have separate bot user id.
+func (client *Client) FetchBotUser() (User, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, "/users/@me", nil)
+	if err != nil {
+		return User{}, err
+	}
+
+	res := User{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return User{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches user that authorized your app through OAuth2. Email & verified fields require "email" scope.
+func (client *Client) FetchCurrentUser(bearerToken string) (User, error) {
+	raw, _, err := client.Rest.RequestWithBearer(bearerToken, http.MethodGet, "/users/@me", nil)
+	if err != nil {
+		return User{}, err
+	}
+
+	res := User{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return User{}, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
+
+// Fetches guilds of user that authorized your app through OAuth2. Requires "guilds" scope.
+func (client *Client) FetchUserGuilds(bearerToken string) ([]PartialGuild, error) {
+	raw, _, err := client.Rest.RequestWithBearer(bearerToken, http.MethodGet, "/users/@me/guilds", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]PartialGuild, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,21 @@
+package tempest
+
+type guildSettingKey struct {
+	guildID Snowflake
+	key     string
+}
+
+// Stores value under key for selected guild (like custom prefix, language or enabled features).
+// Settings live only in memory - they're lost on restart and aren't shared between processes, so use own storage if you need persistence.
+func (client *Client) SetGuildSetting(guildID Snowflake, key string, value interface{}) {
+	client.guildSettings.Store(guildSettingKey{guildID: guildID, key: key}, value)
+}
+
+// Returns value stored with Client.SetGuildSetting. Second value is false when guild has no such setting.
+func (client *Client) GetGuildSetting(guildID Snowflake, key string) (interface{}, bool) {
+	return client.guildSettings.Load(guildSettingKey{guildID: guildID, key: key})
+}
+
+func (client *Client) DeleteGuildSetting(guildID Snowflake, key string) {
+	client.guildSettings.Delete(guildSettingKey{guildID: guildID, key: key})
+}
@@ -0,0 +1,147 @@
+package tempest
+
+import (
+	"sort"
+	"strings"
+)
+
+// Returns ready to register "/help" slash command, built from metadata of commands registered in client.
+// It has optional "command" option (with auto complete) - when provided it shows details of that command, otherwise it lists all slash commands.
+//
+// Help command reads client's registry when triggered so it's fine to register it before other commands:
+//
+//	client.RegisterCommand(client.GenerateHelpCommand())
+func (client *Client) GenerateHelpCommand() Command {
+	return Command{
+		Name:          "help",
+		Description:   "Shows list of available commands or details about specific command.",
+		AvailableInDM: true,
+		Options: []CommandOption{
+			{
+				Type:         STRING_OPTION_TYPE,
+				Name:         "command",
+				Description:  "Name of command to show details about.",
+				AutoComplete: true,
+			},
+		},
+		AutoCompleteHandler: client.helpAutoComplete,
+		SlashCommandHandler: client.helpHandler,
+	}
+}
+
+func (client *Client) helpAutoComplete(itx AutoCompleteInteraction) []Choice {
+	_, value := itx.GetFocusedValue()
+	prefix, _ := value.(string)
+	prefix = strings.ToLower(prefix)
+
+	choices := make([]Choice, 0, MAX_AUTO_COMPLETE_CHOICES)
+	for _, command := range client.helpCommands() {
+		if len(choices) == MAX_AUTO_COMPLETE_CHOICES {
+			break
+		}
+
+		if strings.HasPrefix(command.Name, prefix) {
+			choices = append(choices, Choice{Name: command.Name, Value: command.Name})
+		}
+	}
+
+	return choices
+}
+
+func (client *Client) helpHandler(itx CommandInteraction) {
+	if value, provided := itx.GetOptionValue("command"); provided {
+		name, _ := value.(string)
+
+		client.sMu.RLock()
+		tree, available := client.commands.chatInput[name]
+		var embed *Embed
+		if available {
+			embed = helpCommandEmbed(tree)
+		}
+		client.sMu.RUnlock()
+
+		if embed == nil {
+			itx.SendLinearReply("There's no \"/"+name+"\" command.", true)
+			return
+		}
+
+		itx.SendReply(ResponseMessageData{Embeds: []*Embed{embed}}, true)
+		return
+	}
+
+	var list strings.Builder
+	for _, command := range client.helpCommands() {
+		list.WriteString("`/" + command.Name + "` - " + command.Description + "\n")
+	}
+
+	itx.SendReply(ResponseMessageData{Embeds: []*Embed{{
+		Title:       "Available commands",
+		Description: list.String(),
+	}}}, true)
+}
+
+// Returns all registered slash (chat input) root commands, sorted by name.
+func (client *Client) helpCommands() []Command {
+	client.sMu.RLock()
+	commands := make([]Command, 0, len(client.commands.chatInput))
+	for _, tree := range client.commands.chatInput {
+		commands = append(commands, tree[ROOT_PLACEHOLDER])
+	}
+	client.sMu.RUnlock()
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// Describes single command together with its options and subcommands (embed can hold up to 25 fields).
+func helpCommandEmbed(tree map[string]Command) *Embed {
+	command := tree[ROOT_PLACEHOLDER]
+	embed := &Embed{
+		Title:       "/" + command.Name,
+		Description: command.Description,
+		Fields:      make([]*EmbedField, 0),
+	}
+
+	for _, option := range command.Options {
+		embed.Fields = append(embed.Fields, helpOptionField(option))
+	}
+
+	subNames := make([]string, 0, len(tree)-1)
+	for key := range tree {
+		if key != ROOT_PLACEHOLDER {
+			subNames = append(subNames, key)
+		}
+	}
+	sort.Strings(subNames)
+
+	for _, key := range subNames {
+		subCommand := tree[key]
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:  "/" + command.Name + " " + strings.ReplaceAll(key, "/", " "),
+			Value: subCommand.Description,
+		})
+	}
+
+	if len(embed.Fields) > MAX_EMBED_FIELDS {
+		embed.Fields = embed.Fields[:MAX_EMBED_FIELDS]
+	}
+
+	return embed
+}
+
+func helpOptionField(option CommandOption) *EmbedField {
+	value := option.Description + "\n*" + strings.ToLower(option.Type.String())
+	if option.Required {
+		value += ", required*"
+	} else {
+		value += ", optional*"
+	}
+
+	return &EmbedField{
+		Name:   option.Name,
+		Value:  value,
+		Inline: true,
+	}
+}
@@ -2,37 +2,43 @@ package tempest
 
 import (
 	"crypto/ed25519"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/sugawarayuuta/sonnet"
 )
 
 func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, client.responseMessages.MethodNotAllowed, http.StatusMethodNotAllowed)
 		return
 	}
 
-	verified := verifyRequest(r, ed25519.PublicKey(client.PublicKey))
-	if !verified {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	defer r.Body.Close()
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 		return
 	}
 
-	buf, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		panic(err) // Should never happen
+	// Verify signature against received bytes before spending any time on parsing them.
+	if !verifySignature(r.Header, buf, ed25519.PublicKey(client.PublicKey)) {
+		http.Error(w, client.responseMessages.Unauthorized, http.StatusUnauthorized)
+		return
 	}
 
 	var extractor InteractionTypeExtractor
 	err = sonnet.Unmarshal(buf, &extractor)
 	if err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 		panic(err) // Should never happen
 	}
-	defer r.Body.Close()
+
+	if client.debugInteractions && client.logger != nil {
+		client.logInteraction(buf)
+	}
 
 	switch extractor.Type {
 	case PING_INTERACTION_TYPE:
@@ -43,53 +49,88 @@ func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 		var interaction CommandInteraction
 		err := sonnet.Unmarshal(buf, &interaction)
 		if err != nil {
-			http.Error(w, "bad request", http.StatusBadRequest)
+			http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 			panic(err) // Should never happen
 		}
 
+		interaction.ctx = r.Context()
+
 		command, itx, available := client.seekCommand(interaction)
+		itx.options = &optionsCache{}
 		if !available {
+			if client.unknownCommandHandler != nil {
+				w.WriteHeader(http.StatusNoContent)
+				client.unknownCommandHandler(itx)
+				return
+			}
+
 			w.Header().Add("Content-Type", "application/json")
-			w.Write(private_UNKNOWN_COMMAND_RESPONSE_RAW_BODY)
+			w.Write(client.unknownCommandResponse)
 			return
 		}
 
 		w.WriteHeader(http.StatusNoContent)
 
-		if !command.AvailableInDM && interaction.GuildID == 0 {
-			w.WriteHeader(http.StatusNoContent)
+		if !command.AvailableInDM && interaction.GuildID.IsZero() {
 			return
 		}
 
+		itx.state = &interactionState{received: time.Now(), done: make(chan struct{})}
+		itx.state.deadline = time.AfterFunc(client.responseDeadline, itx.state.finish)
+
+		// Commands rejected by middleware are dropped, so client's timers are armed only after it passes.
 		if client.commandMiddlewareHandler != nil && !client.commandMiddlewareHandler(itx) {
 			return
 		}
 
-		command.SlashCommandHandler(itx)
+		if client.autoDefer {
+			itx.state.deferTimer = time.AfterFunc(client.autoDeferDelay, itx.autoDefer)
+		}
+
+		if client.timeoutResponse != nil {
+			content := *client.timeoutResponse
+			itx.state.mu.Lock()
+			itx.state.timeout = time.AfterFunc(client.responseTimeout, func() { itx.respondOnTimeout(content) })
+			itx.state.mu.Unlock()
+		}
+
+		// Client responds on handler's behalf only while it's still running, returning without reply is handler's choice.
+		defer itx.state.stopTimers()
+		client.executeCommand(commandPath(interaction), command, itx)
 		return
 	case MESSAGE_COMPONENT_INTERACTION_TYPE:
 		var itx ComponentInteraction
 		err := sonnet.Unmarshal(buf, &itx)
 		if err != nil {
-			http.Error(w, "bad request", http.StatusBadRequest)
+			http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 			panic(err) // Should never happen
 		}
 
+		itx.ctx = r.Context()
+
+		// Handlers are checked in order: static (RegisterComponent), scoped, queued (AwaitComponent) and finally ComponentHandler.
+		// With ClientOptions.QueuedComponentsTakePriority, queued listeners are checked first instead.
 		itx.Client = client
+		if client.queuedComponentsFirst && client.sendQueuedComponent(w, itx) {
+			return
+		}
+
+		client.sMu.RLock()
 		fn, available := client.components[itx.Data.CustomID]
+		client.sMu.RUnlock()
 		if available && fn != nil {
 			itx.w = w
 			fn(itx)
 			return
 		}
 
-		client.qMu.RLock()
-		signalChannel, available := client.queuedComponents[itx.Data.CustomID]
-		client.qMu.RUnlock()
-		if available && signalChannel != nil {
-			w.Header().Add("Content-Type", "application/json")
-			w.Write(private_ACKNOWLEDGE_RESPONSE_RAW_BODY)
-			signalChannel <- &itx
+		if fn, available := client.seekScopedComponent(itx); available {
+			itx.w = w
+			fn(itx)
+			return
+		}
+
+		if !client.queuedComponentsFirst && client.sendQueuedComponent(w, itx) {
 			return
 		}
 
@@ -103,11 +144,14 @@ func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 		var interaction CommandInteraction
 		err := sonnet.Unmarshal(buf, &interaction)
 		if err != nil {
-			http.Error(w, "bad request", http.StatusBadRequest)
+			http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 			panic(err) // Should never happen
 		}
 
+		interaction.ctx = r.Context()
+
 		command, itx, available := client.seekCommand(interaction)
+		itx.options = &optionsCache{}
 		if !available || command.AutoCompleteHandler == nil || len(command.Options) == 0 {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -132,11 +176,16 @@ func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 		var itx ModalInteraction
 		err := sonnet.Unmarshal(buf, &itx)
 		if err != nil {
-			http.Error(w, "bad request", http.StatusBadRequest)
+			http.Error(w, client.responseMessages.BadRequest, http.StatusBadRequest)
 			panic(err) // Should never happen
 		}
 
+		itx.ctx = r.Context()
+
+		itx.Client = client
+		client.sMu.RLock()
 		fn, available := client.modals[itx.Data.CustomID]
+		client.sMu.RUnlock()
 		if available && fn != nil {
 			itx.w = w
 			fn(itx)
@@ -144,12 +193,13 @@ func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 		}
 
 		client.qMu.RLock()
-		signalChannel, available := client.queuedModals[itx.Data.CustomID]
+		queue, available := client.queuedModals[itx.Data.CustomID]
 		client.qMu.RUnlock()
-		if available && signalChannel != nil {
+		if available {
 			w.Header().Add("Content-Type", "application/json")
 			w.Write(private_ACKNOWLEDGE_RESPONSE_RAW_BODY)
-			signalChannel <- &itx
+			queue.send(&itx)
+			return
 		}
 
 		if client.modalHandler != nil {
@@ -160,3 +210,65 @@ func (client *Client) handleRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Writes structured (key=value) debug line with basic interaction info and its raw body.
+func (client *Client) logInteraction(buf []byte) {
+	var extractor interactionDebugExtractor
+	if err := sonnet.Unmarshal(buf, &extractor); err != nil {
+		client.logger.Printf("DEBUG incoming interaction parse_error=%q body=%s", err.Error(), buf)
+		return
+	}
+
+	var userID Snowflake
+	if extractor.Member != nil && extractor.Member.User != nil {
+		userID = extractor.Member.User.ID
+	} else if extractor.User != nil {
+		userID = extractor.User.ID
+	}
+
+	client.logger.Printf(
+		"DEBUG incoming interaction type=%s guild_id=%s channel_id=%s user_id=%s body=%s",
+		extractor.Type, extractor.GuildID, extractor.ChannelID, userID, buf,
+	)
+}
+
+// Runs command handler, records its stats (see Client.CommandStats) and reports its execution to OnCommandExecuted hook (if set).
+// Handler's panic is reported as error and then re-panicked so it behaves same as without hook.
+func (client *Client) executeCommand(path string, command Command, itx CommandInteraction) {
+	start := time.Now()
+	defer func() {
+		r := recover()
+		duration := time.Since(start)
+
+		var err error
+		if r != nil {
+			err = fmt.Errorf("command handler panicked: %v", r)
+		}
+
+		client.recordCommandStat(path, start, duration, err != nil)
+		if client.onCommandExecuted != nil {
+			client.onCommandExecuted(command, itx, duration, err)
+		}
+
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	command.SlashCommandHandler(itx)
+}
+
+// Acknowledges component interaction and passes it to listener created by Client.AwaitComponent (if there's any).
+func (client *Client) sendQueuedComponent(w http.ResponseWriter, itx ComponentInteraction) bool {
+	client.qMu.RLock()
+	queue, available := client.queuedComponents[itx.Data.CustomID]
+	client.qMu.RUnlock()
+	if !available {
+		return false
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Write(private_ACKNOWLEDGE_RESPONSE_RAW_BODY)
+	queue.send(&itx)
+	return true
+}
@@ -3,24 +3,73 @@ package tempest
 import (
 	"errors"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sugawarayuuta/sonnet"
 )
 
+type SyncOptions struct {
+	CommandsToInclude []string // Names of root commands to sync (whitelist). Leave empty to sync all registered commands.
+	CommandsToExclude []string // Names of root commands to skip (blacklist). Takes priority over CommandsToInclude.
+	Parallelism       uint     // Max number of guilds synced at the same time. Ignored for global sync. (default: 5)
+}
+
 func (client *Client) RegisterCommand(command Command) error {
 	if client.running {
 		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
 	}
 
-	if _, exists := client.commands[command.Name]; exists {
-		return errors.New("client already has registered \"" + command.Name + "\" slash command (name already in use)")
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	return client.commands.register(command)
+}
+
+// Removes command (together with all its subcommands) of any type from client's registry. Unlike register methods, it can be used while client is running.
+// It doesn't touch commands registered on Discord's side - call Client.SyncCommands afterwards to update them.
+func (client *Client) DeregisterCommand(name string) error {
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	if !client.commands.remove(name) {
+		return ErrCommandNotFound
+	}
+
+	return nil
+}
+
+// Registers multiple commands at once. Whole batch is validated first (including duplicates within batch itself)
+// so on error client's registry stays untouched. Returned error combines all problems found.
+func (client *Client) RegisterCommands(commands ...Command) error {
+	if client.running {
+		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
+	}
+
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	// Validate whole batch against copy of registry so duplicates within batch itself are caught too.
+	batch := newCommandRegistry()
+	for _, trees := range client.commands.all() {
+		for name, tree := range trees {
+			batch.trees(tree[ROOT_PLACEHOLDER].Type)[name] = tree
+		}
+	}
+
+	var errs []error
+	for _, command := range commands {
+		if err := batch.register(command); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	if command.Type == 0 {
-		command.Type = CHAT_INPUT_COMMAND_TYPE
+	if len(errs) != 0 {
+		return errors.Join(errs...)
 	}
 
-	tree := make(map[string]Command)
-	tree[ROOT_PLACEHOLDER] = command
-	client.commands[command.Name] = tree
+	client.commands = batch
 	return nil
 }
 
@@ -29,15 +78,72 @@ func (client *Client) RegisterSubCommand(subCommand Command, rootCommandName str
 		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
 	}
 
-	if _, available := client.commands[rootCommandName]; !available {
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	tree, available := client.commands.chatInput[rootCommandName]
+	if !available {
 		return errors.New("missing \"" + rootCommandName + "\" slash command in registry (root command needs to be registered in client before adding subcommands)")
 	}
 
-	if _, available := client.commands[rootCommandName][subCommand.Name]; available {
+	if _, available := tree[subCommand.Name]; available {
 		return errors.New("client already has registered \"" + rootCommandName + "@" + subCommand.Name + "\" slash subcommand")
 	}
 
-	client.commands[rootCommandName][subCommand.Name] = subCommand
+	for key := range tree {
+		if strings.HasPrefix(key, subCommand.Name+"/") {
+			return errors.New("client already has registered \"" + rootCommandName + "@" + subCommand.Name + "\" slash subcommand group (subcommand name cannot be the same as group name)")
+		}
+	}
+
+	tree[subCommand.Name] = subCommand
+	return nil
+}
+
+// Registers subcommand inside subcommand group (example: "/tag manage delete" where "manage" is group name).
+// Group is created automatically together with its first subcommand, its name is also used as its description.
+// Discord allows up to 25 subcommands and/or groups per root command and 25 subcommands per group.
+func (client *Client) RegisterGroupSubCommand(subCommand Command, rootCommandName string, groupName string) error {
+	if client.running {
+		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
+	}
+
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	tree, available := client.commands.chatInput[rootCommandName]
+	if !available {
+		return errors.New("missing \"" + rootCommandName + "\" slash command in registry (root command needs to be registered in client before adding subcommands)")
+	}
+
+	if _, available := tree[groupName]; available {
+		return errors.New("client already has registered \"" + rootCommandName + "@" + groupName + "\" slash subcommand (group name cannot be the same as subcommand name)")
+	}
+
+	key := groupName + "/" + subCommand.Name
+	if _, available := tree[key]; available {
+		return errors.New("client already has registered \"" + rootCommandName + "@" + groupName + "@" + subCommand.Name + "\" slash subcommand")
+	}
+
+	tree[key] = subCommand
+	return nil
+}
+
+// Registers root command built with CommandBuilder together with all of its subcommands.
+func (client *Client) RegisterCommandBuilder(builder *CommandBuilder) error {
+	err := client.RegisterCommand(builder.buildRoot())
+	if err != nil {
+		return err
+	}
+
+	rootName := builder.command.Name
+	for _, subCommand := range builder.BuildSubCommands() {
+		err = client.RegisterSubCommand(subCommand, rootName)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -47,6 +153,9 @@ func (client *Client) RegisterComponent(customIDs []string, fn func(ComponentInt
 		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
 	}
 
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
 	for _, ID := range customIDs {
 		_, exists := client.components[ID]
 		if exists {
@@ -61,12 +170,92 @@ func (client *Client) RegisterComponent(customIDs []string, fn func(ComponentInt
 	return nil
 }
 
+// Bind function to button with matching custom id. Works same as RegisterComponent but handler receives ButtonInteraction.
+func (client *Client) RegisterButton(customID string, fn func(ButtonInteraction)) error {
+	return client.RegisterComponent([]string{customID}, func(itx ComponentInteraction) {
+		fn(ButtonInteraction{itx})
+	})
+}
+
+// Bind function to select menu with matching custom id. Works same as RegisterComponent but handler receives SelectMenuInteraction.
+func (client *Client) RegisterSelectMenu(customID string, fn func(SelectMenuInteraction)) error {
+	return client.RegisterComponent([]string{customID}, func(itx ComponentInteraction) {
+		fn(SelectMenuInteraction{itx})
+	})
+}
+
+// Bind function to component with matching custom id that runs only when clicked by target user (example: confirmation button only original invoker can use).
+// Unlike RegisterComponent, it can be used while client is running. Call returned function to remove handler.
+// Interactions from other users fall through to regular handling (queued components & ClientOptions.ComponentHandler).
+func (client *Client) RegisterComponentForUser(customID string, userID Snowflake, fn func(ComponentInteraction)) func() {
+	return client.registerScopedComponent(customID, &scopedComponent{userID: userID, fn: fn})
+}
+
+// Bind function to component with matching custom id that runs only when used within target guild.
+// Unlike RegisterComponent, it can be used while client is running. Call returned function to remove handler.
+// Interactions from other guilds fall through to regular handling (queued components & ClientOptions.ComponentHandler).
+func (client *Client) RegisterComponentForGuild(customID string, guildID Snowflake, fn func(ComponentInteraction)) func() {
+	return client.registerScopedComponent(customID, &scopedComponent{guildID: guildID, fn: fn})
+}
+
+func (client *Client) registerScopedComponent(customID string, scoped *scopedComponent) func() {
+	client.qMu.Lock()
+	if client.scopedComponents == nil {
+		client.scopedComponents = make(map[string][]*scopedComponent)
+	}
+	client.scopedComponents[customID] = append(client.scopedComponents[customID], scoped)
+	client.qMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			client.qMu.Lock()
+			defer client.qMu.Unlock()
+
+			list := client.scopedComponents[customID]
+			for i, entry := range list {
+				if entry == scoped {
+					list = append(list[:i:i], list[i+1:]...)
+					break
+				}
+			}
+
+			if len(list) == 0 {
+				delete(client.scopedComponents, customID)
+			} else {
+				client.scopedComponents[customID] = list
+			}
+		})
+	}
+}
+
+// Returns first scoped component handler matching interaction.
+func (client *Client) seekScopedComponent(itx ComponentInteraction) (func(ComponentInteraction), bool) {
+	client.qMu.RLock()
+	defer client.qMu.RUnlock()
+
+	for _, scoped := range client.scopedComponents[itx.Data.CustomID] {
+		if scoped.matches(itx) {
+			return scoped.fn, true
+		}
+	}
+
+	return nil, false
+}
+
 // Bind function to modal with matching custom id. App will automatically run bound function whenever receiving modal interaction with matching custom id.
+// Unlike Client.AwaitModal, handler stays registered until removed with Client.DeregisterModal so it's fine to use it for reusable modals (it can be called at runtime).
 func (client *Client) RegisterModal(customID string, fn func(ModalInteraction)) error {
-	if client.running {
-		return errors.New("client is already running (cannot modify client's internal cache after it launches)")
+	client.qMu.RLock()
+	_, queued := client.queuedModals[customID]
+	client.qMu.RUnlock()
+	if queued {
+		return errors.New("client already awaits \"" + customID + "\" modal (custom id already in use)")
 	}
 
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
 	_, exists := client.modals[customID]
 	if exists {
 		return errors.New("client already has registered \"" + customID + "\" modal (custom id already in use)")
@@ -76,18 +265,143 @@ func (client *Client) RegisterModal(customID string, fn func(ModalInteraction))
 	return nil
 }
 
+// Removes modal handler registered with Client.RegisterModal. Can be called at runtime.
+func (client *Client) DeregisterModal(customID string) error {
+	client.sMu.Lock()
+	defer client.sMu.Unlock()
+
+	if _, exists := client.modals[customID]; !exists {
+		return ErrModalNotFound
+	}
+
+	delete(client.modals, customID)
+	return nil
+}
+
 // Sync currently cached slash commands to discord API. By default it'll try to make (bulk) global update (limit 100 updates per day), provide array with guild id snowflakes to update data only for specific guilds.
-// You can also add second param -> slice with all command names you want to update (whitelist). There's also third, boolean param that when = true will reverse wishlist to work as blacklist.
-func (client *Client) SyncCommands(guildIDs []Snowflake, whitelist []string, switchMode bool) error {
-	payload := client.parseCommands(whitelist, switchMode)
+// Guilds are synced concurrently (see SyncOptions.Parallelism) and failing guild doesn't stop others - all errors are collected and returned together (nil on success).
+func (client *Client) SyncCommands(guildIDs []Snowflake, options SyncOptions) []error {
+	payload := client.parseCommands(options.CommandsToInclude, options.CommandsToExclude)
 
 	if len(guildIDs) == 0 {
-		_, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/commands", payload)
+		_, _, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/commands", payload)
+		if err != nil {
+			return []error{err}
+		}
+		return nil
+	}
+
+	parallelism := options.Parallelism
+	if parallelism == 0 {
+		parallelism = DEFAULT_SYNC_PARALLELISM
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	semaphore := make(chan struct{}, parallelism)
+
+	for _, guildID := range guildIDs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(guildID Snowflake) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			_, _, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/guilds/"+guildID.String()+"/commands", payload)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, errors.New("failed to sync commands for \""+guildID.String()+"\" guild: "+err.Error()))
+				mu.Unlock()
+			}
+		}(guildID)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// Works like Client.SyncCommands but instead of bulk overwrite, it compares local registry with commands currently registered on Discord's side
+// (using Command.CommandHash) and only creates, updates or deletes commands that changed. Provide guild ids to sync guild commands (global by default).
+// Returns how many commands were added, updated and deleted in total. Failing guild doesn't stop others - all errors are joined together.
+func (client *Client) SyncChangedCommands(guildIDs []Snowflake) (added int, updated int, deleted int, err error) {
+	local := client.parseCommands(nil, nil)
+
+	if len(guildIDs) == 0 {
+		return client.syncChangedCommands(local, nil)
+	}
+
+	var errs []error
+	for _, guildID := range guildIDs {
+		a, u, d, err := client.syncChangedCommands(local, []Snowflake{guildID})
+		added, updated, deleted = added+a, updated+u, deleted+d
+		if err != nil {
+			errs = append(errs, errors.New("failed to sync commands for \""+guildID.String()+"\" guild: "+err.Error()))
+		}
+	}
+
+	return added, updated, deleted, errors.Join(errs...)
+}
+
+func (client *Client) syncChangedCommands(local []Command, guildID []Snowflake) (added int, updated int, deleted int, err error) {
+	// Localizations are skipped by default, without them localized commands would never match.
+	remote, err := client.fetchAllCommands("?with_localizations=true", guildID)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	route := client.commandsRoute(guildID)
+	remoteByKey := make(map[string]Command, len(remote))
+	for _, command := range remote {
+		remoteByKey[command.Type.String()+"/"+command.Name] = command
+	}
+
+	for _, command := range local {
+		key := command.Type.String() + "/" + command.Name
+		existing, exists := remoteByKey[key]
+		delete(remoteByKey, key)
+
+		if !exists {
+			if _, _, err := client.Rest.Request(http.MethodPost, route, command); err != nil {
+				return added, updated, deleted, err
+			}
+			added++
+			continue
+		}
+
+		if existing.CommandHash() != command.CommandHash() {
+			if _, _, err := client.Rest.Request(http.MethodPatch, route+"/"+existing.ID.String(), command); err != nil {
+				return added, updated, deleted, err
+			}
+			updated++
+		}
+	}
+
+	for _, command := range remoteByKey {
+		if _, _, err := client.Rest.Request(http.MethodDelete, route+"/"+command.ID.String(), nil); err != nil {
+			return added, updated, deleted, err
+		}
+		deleted++
+	}
+
+	return added, updated, deleted, nil
+}
+
+// Removes all commands registered on Discord's side (it doesn't touch client's local cache). Call without arguments to clear global commands or provide guild ids to clear commands from specific guilds.
+// Mostly useful for teardown and testing scenarios.
+func (client *Client) DeleteAllCommands(guildIDs ...Snowflake) error {
+	payload := make([]Command, 0)
+
+	if len(guildIDs) == 0 {
+		_, _, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/commands", payload)
 		return err
 	}
 
 	for _, guildID := range guildIDs {
-		_, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/guilds/"+guildID.String()+"/commands", payload)
+		_, _, err := client.Rest.Request(http.MethodPut, "/applications/"+client.ApplicationID.String()+"/guilds/"+guildID.String()+"/commands", payload)
 		if err != nil {
 			return err
 		}
@@ -96,97 +410,179 @@ func (client *Client) SyncCommands(guildIDs []Snowflake, whitelist []string, swi
 	return nil
 }
 
-func (client *Client) seekCommand(itx CommandInteraction) (Command, CommandInteraction, bool) {
-	if len(itx.Data.Options) != 0 && itx.Data.Options[0].Type == SUB_OPTION_TYPE {
-		command, available := client.commands[itx.Data.Name][itx.Data.Options[0].Name]
-		if available {
-			if itx.Member != nil {
-				itx.Member.GuildID = itx.GuildID
-			}
+// Fetches command currently registered on Discord's side. Provide guild id to fetch guild specific command (global by default).
+func (client *Client) FetchCommand(commandID Snowflake, guildID ...Snowflake) (Command, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, client.commandsRoute(guildID)+"/"+commandID.String(), nil)
+	if err != nil {
+		return Command{}, err
+	}
 
-			itx.Data.Name, itx.Data.Options = itx.Data.Options[0].Name, itx.Data.Options[0].Options
-			itx.Client = client
-		}
-		return command, CommandInteraction(itx), available
+	res := Command{}
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return Command{}, errors.New("failed to parse received data from discord")
+	}
+
+	res.ID = commandID
+	return res, nil
+}
+
+// Fetches all commands currently registered on Discord's side. Provide guild id to fetch guild specific commands (global by default).
+// Use it to compare remote state with local registry before calling Client.SyncCommands.
+func (client *Client) FetchAllCommands(guildID ...Snowflake) ([]Command, error) {
+	return client.fetchAllCommands("", guildID)
+}
+
+// Fetches commands from route with extra query string (used to request localizations when comparing commands).
+func (client *Client) fetchAllCommands(query string, guildID []Snowflake) ([]Command, error) {
+	raw, _, err := client.Rest.Request(http.MethodGet, client.commandsRoute(guildID)+query, nil)
+	if err != nil {
+		return nil, err
 	}
 
+	res := make([]Command, 0)
+	err = sonnet.Unmarshal(raw, &res)
+	if err != nil {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	IDs := make([]commandIDExtractor, 0, len(res))
+	err = sonnet.Unmarshal(raw, &IDs)
+	if err != nil || len(IDs) != len(res) {
+		return nil, errors.New("failed to parse received data from discord")
+	}
+
+	for i := range res {
+		res[i].ID = IDs[i].ID
+	}
+
+	return res, nil
+}
+
+// Returns API route to either global or guild commands (only first guild id is used).
+func (client *Client) commandsRoute(guildID []Snowflake) string {
+	if len(guildID) == 0 {
+		return "/applications/" + client.ApplicationID.String() + "/commands"
+	}
+	return "/applications/" + client.ApplicationID.String() + "/guilds/" + guildID[0].String() + "/commands"
+}
+
+func (client *Client) seekCommand(itx CommandInteraction) (Command, CommandInteraction, bool) {
 	if itx.Member != nil {
 		itx.Member.GuildID = itx.GuildID
 	}
 
 	itx.Client = client
-	command, available := client.commands[itx.Data.Name][ROOT_PLACEHOLDER]
+
+	client.sMu.RLock()
+	defer client.sMu.RUnlock()
+
+	tree := client.commands.trees(itx.Data.Type)[itx.Data.Name]
+
+	if len(itx.Data.Options) != 0 && itx.Data.Options[0].Type == SUB_COMMAND_GROUP_OPTION_TYPE {
+		group := itx.Data.Options[0]
+		if len(group.Options) == 0 {
+			return Command{}, CommandInteraction(itx), false
+		}
+
+		command, available := tree[group.Name+"/"+group.Options[0].Name]
+		if available {
+			itx.Data.Name, itx.Data.Options = group.Options[0].Name, group.Options[0].Options
+		}
+		return command, CommandInteraction(itx), available
+	}
+
+	if len(itx.Data.Options) != 0 && itx.Data.Options[0].Type == SUB_OPTION_TYPE {
+		command, available := tree[itx.Data.Options[0].Name]
+		if available {
+			itx.Data.Name, itx.Data.Options = itx.Data.Options[0].Name, itx.Data.Options[0].Options
+		}
+		return command, CommandInteraction(itx), available
+	}
+
+	command, available := tree[ROOT_PLACEHOLDER]
 	return command, CommandInteraction(itx), available
 }
 
 // Parses registered commands into Discord format.
-func (client *Client) parseCommands(whitelist []string, reverseMode bool) []Command {
-	list := make([]Command, len(client.commands))
-	var itx uint32 = 0
+// Empty include list means all commands, commands listed in exclude list are always skipped.
+func (client *Client) parseCommands(include []string, exclude []string) []Command {
+	client.sMu.RLock()
+	defer client.sMu.RUnlock()
 
-	for _, tree := range client.commands {
-		command := tree[ROOT_PLACEHOLDER]
+	list := make([]Command, 0, client.commands.count())
 
-		if len(tree) > 1 {
-			for key, subCommand := range tree {
-				if key == ROOT_PLACEHOLDER {
-					continue
-				}
-
-				command.Options = append(command.Options, CommandOption{
-					Name:        subCommand.Name,
-					Description: subCommand.Description,
-					Type:        SUB_OPTION_TYPE,
-					Options:     subCommand.Options,
-				})
+	for _, trees := range client.commands.all() {
+		for name, tree := range trees {
+			if (len(include) != 0 && !containsString(include, name)) || containsString(exclude, name) {
+				continue
 			}
-		}
 
-		list[itx] = command
-		itx++
+			list = append(list, parseCommandTree(tree))
+		}
 	}
 
-	wls := len(whitelist)
-	if wls == 0 {
-		return list
-	}
+	return list
+}
 
-	itx = 0
+// Merges root command with its subcommands (and subcommand groups) into single command in Discord format.
+func parseCommandTree(tree map[string]Command) Command {
+	command := tree[ROOT_PLACEHOLDER]
 
-	// Work as blacklist
-	if reverseMode {
-		filteredList := make([]Command, len(list)-wls)
+	if len(tree) > 1 {
+		// Copy options so appending subcommands won't modify registered command.
+		options := make([]CommandOption, len(command.Options), len(command.Options)+len(tree)-1)
+		copy(options, command.Options)
 
-		for itx, command := range list {
-			blocked := false
-			for _, cmdName := range whitelist {
-				if command.Name == cmdName {
-					blocked = true
-					break
-				}
+		// Sort subcommands so payload (and Command.CommandHash) doesn't depend on map iteration order.
+		keys := make([]string, 0, len(tree)-1)
+		for key := range tree {
+			if key != ROOT_PLACEHOLDER {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		groups := make(map[string]int) // Group name -> index in options.
+		for _, key := range keys {
+			subCommand := tree[key]
+			option := CommandOption{
+				Name:        subCommand.Name,
+				Description: subCommand.Description,
+				Type:        SUB_OPTION_TYPE,
+				Options:     subCommand.Options,
 			}
 
-			if blocked {
+			groupName, _, grouped := strings.Cut(key, "/")
+			if !grouped {
+				options = append(options, option)
 				continue
 			}
 
-			filteredList[itx] = command
+			index, exists := groups[groupName]
+			if !exists {
+				index = len(options)
+				groups[groupName] = index
+				options = append(options, CommandOption{
+					Name:        groupName,
+					Description: groupName,
+					Type:        SUB_COMMAND_GROUP_OPTION_TYPE,
+				})
+			}
+			options[index].Options = append(options[index].Options, option)
 		}
 
-		return filteredList
+		command.Options = options
 	}
 
-	// Work as whitelist
-	filteredList := make([]Command, wls)
+	return command
+}
 
-	for _, command := range list {
-		for _, cmdName := range whitelist {
-			if command.Name == cmdName {
-				filteredList[itx] = command
-				itx++
-			}
+func containsString(list []string, target string) bool {
+	for _, value := range list {
+		if value == target {
+			return true
 		}
 	}
-
-	return filteredList
+	return false
 }
@@ -0,0 +1,170 @@
+package tempest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegisterCommands(t *testing.T) {
+	client := Client{commands: newCommandRegistry()}
+	client.RegisterCommand(Command{Name: "ping"})
+
+	err := client.RegisterCommands(Command{Name: "echo"}, Command{Name: "ping"}, Command{Name: "echo"})
+	if err == nil {
+		t.Fatal("expected error for duplicated commands")
+	}
+
+	if _, exists := client.commands.chatInput["echo"]; exists {
+		t.Error("registry was modified despite invalid batch")
+	}
+
+	if err := client.RegisterCommands(Command{Name: "echo"}, Command{Name: "avatar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if client.commands.count() != 3 {
+		t.Errorf("expected 3 registered commands, got %d", client.commands.count())
+	}
+}
+
+func TestDeregisterCommand(t *testing.T) {
+	client := Client{commands: newCommandRegistry()}
+	client.RegisterCommand(Command{Name: "tag"})
+	client.RegisterSubCommand(Command{Name: "create"}, "tag")
+
+	if err := client.DeregisterCommand("tag"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, exists := client.commands.chatInput["tag"]; exists {
+		t.Error("command is still in registry")
+	}
+
+	if err := client.DeregisterCommand("tag"); err != ErrCommandNotFound {
+		t.Errorf("expected ErrCommandNotFound, got: %v", err)
+	}
+}
+
+func TestRegisterComponentForUser(t *testing.T) {
+	client := Client{}
+	deregister := client.RegisterComponentForUser("confirm", 1, func(itx ComponentInteraction) {})
+	client.RegisterComponentForGuild("confirm", 2, func(itx ComponentInteraction) {})
+
+	itx := ComponentInteraction{Data: ComponentInteractionData{CustomID: "confirm"}, GuildID: 3, Member: &Member{User: &User{ID: 1}}}
+	if _, available := client.seekScopedComponent(itx); !available {
+		t.Error("expected handler for matching user")
+	}
+
+	itx.Member.User.ID = 4
+	if _, available := client.seekScopedComponent(itx); available {
+		t.Error("expected no handler for other user in other guild")
+	}
+
+	itx.GuildID = 2
+	if _, available := client.seekScopedComponent(itx); !available {
+		t.Error("expected handler for matching guild")
+	}
+
+	deregister()
+	deregister()
+	if len(client.scopedComponents["confirm"]) != 1 {
+		t.Errorf("expected single handler left after deregistering, got %d", len(client.scopedComponents["confirm"]))
+	}
+}
+
+func TestRegisterGroupSubCommand(t *testing.T) {
+	client := Client{commands: newCommandRegistry()}
+	client.RegisterCommand(Command{Name: "tag", Description: "Manages tags."})
+	client.RegisterSubCommand(Command{Name: "show", Description: "Shows tag."}, "tag")
+	client.RegisterGroupSubCommand(Command{Name: "create", Description: "Creates tag."}, "tag", "manage")
+	client.RegisterGroupSubCommand(Command{Name: "delete", Description: "Deletes tag."}, "tag", "manage")
+
+	if err := client.RegisterGroupSubCommand(Command{Name: "x"}, "tag", "show"); err == nil {
+		t.Error("expected error for group named like existing subcommand")
+	}
+
+	if err := client.RegisterSubCommand(Command{Name: "manage"}, "tag"); err == nil {
+		t.Error("expected error for subcommand named like existing group")
+	}
+
+	commands := client.parseCommands(nil, nil)
+	if len(commands) != 1 || len(commands[0].Options) != 2 {
+		t.Fatalf("unexpected parsed commands: %+v", commands)
+	}
+
+	group := commands[0].Options[0]
+	if group.Type != SUB_COMMAND_GROUP_OPTION_TYPE || group.Name != "manage" || len(group.Options) != 2 || group.Options[1].Name != "delete" {
+		t.Errorf("unexpected subcommand group: %+v", group)
+	}
+
+	command, itx, available := client.seekCommand(CommandInteraction{Data: CommandInteractionData{
+		Name: "tag",
+		Options: []*CommandInteractionOption{{
+			Name: "manage",
+			Type: SUB_COMMAND_GROUP_OPTION_TYPE,
+			Options: []*CommandInteractionOption{{
+				Name:    "delete",
+				Type:    SUB_OPTION_TYPE,
+				Options: []*CommandInteractionOption{{Name: "name", Type: STRING_OPTION_TYPE, Value: "rules"}},
+			}},
+		}},
+	}})
+
+	if !available || command.Name != "delete" || itx.Data.Name != "delete" || len(itx.Data.Options) != 1 {
+		t.Errorf("failed to route subcommand group interaction: %+v", itx.Data)
+	}
+}
+
+func TestDeregisterModal(t *testing.T) {
+	client := NewClient(ClientOptions{})
+	client.running = true
+
+	if err := client.RegisterModal("settings", func(ModalInteraction) {}); err != nil {
+		t.Fatalf("expected modal to be registered at runtime, got: %s", err)
+	}
+
+	if err := client.RegisterModal("settings", func(ModalInteraction) {}); err == nil {
+		t.Error("expected error for duplicated modal")
+	}
+
+	if _, _, err := client.AwaitModal("settings", time.Minute); err == nil {
+		t.Error("expected AwaitModal to reject custom id of registered modal")
+	}
+
+	if err := client.DeregisterModal("settings"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeregisterModal("settings"); err != ErrModalNotFound {
+		t.Errorf("expected ErrModalNotFound, got: %v", err)
+	}
+}
+
+func TestCommandNamesUniquePerType(t *testing.T) {
+	client := Client{commands: newCommandRegistry()}
+
+	if err := client.RegisterCommand(Command{Name: "info", Description: "Shows info."}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.RegisterCommand(Command{Name: "info", Type: USER_COMMAND_TYPE}); err != nil {
+		t.Fatalf("expected user command to share name with slash command, got: %s", err)
+	}
+
+	if err := client.RegisterCommands(Command{Name: "info", Type: MESSAGE_COMMAND_TYPE}, Command{Name: "info", Type: USER_COMMAND_TYPE}); err == nil {
+		t.Error("expected error for duplicated user command")
+	}
+
+	if client.commands.count() != 2 {
+		t.Errorf("expected 2 registered commands, got %d", client.commands.count())
+	}
+
+	command, _, available := client.seekCommand(CommandInteraction{Data: CommandInteractionData{Name: "info", Type: USER_COMMAND_TYPE}})
+	if !available || command.Type != USER_COMMAND_TYPE {
+		t.Errorf("expected user command to be found, got: %+v", command)
+	}
+
+	if err := client.DeregisterCommand("info"); err != nil || client.commands.count() != 0 {
+		t.Errorf("expected command of all types to be removed, got: %v (%d left)", err, client.commands.count())
+	}
+}
@@ -0,0 +1,70 @@
+package tempest
+
+import (
+	"strings"
+	"time"
+)
+
+// Usage statistics of single command, collected by client around each handler call.
+type CommandStat struct {
+	Invocations   uint64
+	LastInvoked   time.Time
+	TotalDuration time.Duration // Sum of all handler execution times.
+	Errors        uint64        // Number of handler calls that panicked.
+}
+
+// Returns average handler execution time.
+func (stat CommandStat) AverageDuration() time.Duration {
+	if stat.Invocations == 0 {
+		return 0
+	}
+	return stat.TotalDuration / time.Duration(stat.Invocations)
+}
+
+// Returns snapshot of usage statistics of all invoked commands. Keys are full command paths, like "tag" or "tag manage delete" for subcommands.
+func (client *Client) CommandStats() map[string]CommandStat {
+	client.statsMu.Lock()
+	defer client.statsMu.Unlock()
+
+	snapshot := make(map[string]CommandStat, len(client.commandStats))
+	for path, stat := range client.commandStats {
+		snapshot[path] = stat
+	}
+	return snapshot
+}
+
+// Clears all collected command statistics.
+func (client *Client) ResetCommandStats() {
+	client.statsMu.Lock()
+	client.commandStats = make(map[string]CommandStat)
+	client.statsMu.Unlock()
+}
+
+func (client *Client) recordCommandStat(path string, start time.Time, duration time.Duration, failed bool) {
+	client.statsMu.Lock()
+	defer client.statsMu.Unlock()
+
+	if client.commandStats == nil {
+		client.commandStats = make(map[string]CommandStat)
+	}
+
+	stat := client.commandStats[path]
+	stat.Invocations++
+	stat.LastInvoked = start
+	stat.TotalDuration += duration
+	if failed {
+		stat.Errors++
+	}
+	client.commandStats[path] = stat
+}
+
+// Returns full path of invoked command (root name followed by subcommand group & subcommand names), based on raw interaction data.
+func commandPath(itx CommandInteraction) string {
+	path := []string{itx.Data.Name}
+	options := itx.Data.Options
+	for len(options) != 0 && options[0] != nil && (options[0].Type == SUB_COMMAND_GROUP_OPTION_TYPE || options[0].Type == SUB_OPTION_TYPE) {
+		path = append(path, options[0].Name)
+		options = options[0].Options
+	}
+	return strings.Join(path, " ")
+}
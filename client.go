@@ -1,21 +1,81 @@
 package tempest
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
+	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
+
+	"github.com/sugawarayuuta/sonnet"
 )
 
 type ClientOptions struct {
-	ApplicationID     Snowflake // The app's user id. (default: <nil>)
-	PublicKey         string    // Hash like key used to verify incoming payloads from Discord. (default: <nil>)
-	Rest              *Rest
-	CommandMiddleware func(itx CommandInteraction) bool // Function that runs before each command. Return type signals whether to continue command execution (return with false to stop early).
-	ComponentHandler  func(itx ComponentInteraction)    // Function that runs for each unhandled component.
-	ModalHandler      func(itx ModalInteraction)        // Function that runs for each unhandled modal.
+	ApplicationID         Snowflake // The app's user id. (default: <nil>)
+	PublicKey             string    // Hash like key used to verify incoming payloads from Discord. (default: <nil>)
+	Rest                  *Rest
+	CommandMiddleware     func(itx CommandInteraction) bool // Function that runs before each command. Return type signals whether to continue command execution (return with false to stop early).
+	ComponentHandler      func(itx ComponentInteraction)    // Function that runs for each unhandled component.
+	ModalHandler          func(itx ModalInteraction)        // Function that runs for each unhandled modal.
+	UnknownCommandHandler func(itx CommandInteraction)      // Function that runs instead of default reply whenever app receives command missing in client's registry (usually sign of unsynced commands).
+	Logger                *log.Logger                       // Optional logger used for library's diagnostic messages. (default: <nil>)
+	DebugInteractions     bool                              // Whether to log every verified incoming interaction (with raw body) at DEBUG level. Requires Logger to be set.
+	AutoDefer             bool                              // Whether client should defer command interactions on handler's behalf when they weren't acknowledged within 2.5s (and handler is still running).
+	ShutdownTimeout       time.Duration                     // Max time ListenAndServeGraceful waits for in-flight interactions before exiting. (default: 10s)
+	AutoRestart           bool                              // Whether client should restart its web server when it stops with error (like when network interface goes down).
+	RestartDelay          time.Duration                     // Wait time before each restart attempt. Requires AutoRestart to be enabled. (default: 5s)
+	MaxRestarts           uint                              // Number of restart attempts after which client gives up and returns last error. Use 0 for no limit.
+	MaxUploadSize         int64                             // Upload limit used by SendMessageWithFiles, set it to MAX_BOOSTED_UPLOAD_SIZE if bot only runs in boosted guilds. (default: 8 MiB)
+
+	// Whether listeners created by Client.AwaitComponent should receive interactions before static & scoped component handlers
+	// registered for the same custom id. Useful for multi-step flows that temporarily shadow persistent buttons. (default: false)
+	QueuedComponentsTakePriority bool
+
+	// Function that runs after each command handler returns. Err is set when handler panicked. Useful for collecting metrics.
+	OnCommandExecuted func(command Command, interaction CommandInteraction, duration time.Duration, err error)
+
+	// Fallback reply sent when command handler doesn't respond within ResponseTimeout (instead of Discord's "This interaction failed").
+	// Leave it <nil> to disable timeout guard.
+	TimeoutResponse *ResponseMessageData
+	ResponseTimeout time.Duration // Time after which TimeoutResponse is sent. Requires TimeoutResponse to be set. (default: 2.9s)
+
+	// Texts of client's built-in replies, empty fields fall back to default (English) messages.
+	ResponseMessages ResponseMessages
+}
+
+// Texts used by client's built-in webhook level replies. Override them to localize bot for non-English servers.
+type ResponseMessages struct {
+	MethodNotAllowed string // Sent with 405 status for requests other than POST. (default: "method not allowed")
+	Unauthorized     string // Sent with 401 status for requests that failed signature verification. (default: "unauthorized")
+	BadRequest       string // Sent with 400 status for malformed request bodies. (default: "bad request")
+	UnknownCommand   string // Content of ephemeral message sent in reply to command missing in client's registry (unless UnknownCommandHandler is set).
+}
+
+func (messages ResponseMessages) withDefaults() ResponseMessages {
+	if messages.MethodNotAllowed == "" {
+		messages.MethodNotAllowed = "method not allowed"
+	}
+
+	if messages.Unauthorized == "" {
+		messages.Unauthorized = "unauthorized"
+	}
+
+	if messages.BadRequest == "" {
+		messages.BadRequest = "bad request"
+	}
+
+	if messages.UnknownCommand == "" {
+		messages.UnknownCommand = "Oh snap! It looks like you tried to trigger (/) unknown command. Please report this bug to bot owner."
+	}
+
+	return messages
 }
 
 // Please avoid creating raw Client struct unless you know what you're doing. Use CreateClient function instead.
@@ -24,50 +84,162 @@ type Client struct {
 	ApplicationID Snowflake
 	PublicKey     ed25519.PublicKey
 
-	commands   map[string]map[string]Command         // Internal cache for commands. Only writeable before starting application!
+	sMu        sync.RWMutex                          // Shared mutex for static commands, components & modals (commands & modals can be modified at runtime).
+	commands   *commandRegistry                      // Internal cache for commands. Only writeable before starting application (except for removal)!
 	components map[string]func(ComponentInteraction) // Internal cache for "static" components. Only writeable before starting application!
-	modals     map[string]func(ModalInteraction)     // Internal cache for "static" modals. Only writeable before starting application!
+	modals     map[string]func(ModalInteraction)     // Internal cache for "static" modals. Can be modified at runtime.
 
 	qMu              sync.RWMutex // Shated mutex for dynamic, components & modals.
-	queuedComponents map[string]chan *ComponentInteraction
-	queuedModals     map[string]chan *ModalInteraction
+	queuedComponents map[string]*componentQueue
+	queuedModals     map[string]*modalQueue
+	scopedComponents map[string][]*scopedComponent // Components bound to specific user or guild, can be modified at runtime.
 
 	commandMiddlewareHandler func(itx CommandInteraction) bool // From options, called before each slash command.
 	componentHandler         func(itx ComponentInteraction)
 	modalHandler             func(itx ModalInteraction)
+	unknownCommandHandler    func(itx CommandInteraction)
+	onCommandExecuted        func(command Command, interaction CommandInteraction, duration time.Duration, err error)
+	logger                   *log.Logger
+	debugInteractions        bool
+	autoDefer                bool
+	autoDeferDelay           time.Duration // Always AUTO_DEFER_DELAY, overridden only by tests.
+	responseDeadline         time.Duration // Always INTERACTION_RESPONSE_DEADLINE, overridden only by tests.
+	queuedComponentsFirst    bool
+	shutdownTimeout          time.Duration
+	timeoutResponse          *ResponseMessageData
+	responseTimeout          time.Duration
+	autoRestart              bool
+	restartDelay             time.Duration
+	maxRestarts              uint
+	maxUploadSize            int64
+	responseMessages         ResponseMessages
+	unknownCommandResponse   []byte // Prepared reply with ResponseMessages.UnknownCommand as it never changes.
+	statsMu                  sync.Mutex
+	commandStats             map[string]CommandStat
+	guildSettings            sync.Map // Keyed by guildSettingKey, see Client.SetGuildSetting.
+	serverMu                 sync.Mutex
+	server                   *http.Server
 	running                  bool // Whether client's web server is already launched.
+	stopped                  bool // Whether client's web server was shut down on purpose (so it shouldn't be restarted).
+}
+
+// Listener created by Client.AwaitComponent. Interactions are sent while holding read lock,
+// so closing channel can never race with pending send (done channel unblocks such sends first).
+type componentQueue struct {
+	mu      sync.RWMutex
+	once    sync.Once
+	closed  bool
+	channel chan *ComponentInteraction
+	done    chan struct{}
+}
+
+func (queue *componentQueue) send(itx *ComponentInteraction) {
+	queue.mu.RLock()
+	defer queue.mu.RUnlock()
+
+	if queue.closed {
+		return
+	}
+
+	select {
+	case queue.channel <- itx:
+	case <-queue.done:
+	}
+}
+
+// Listener created by Client.AwaitModal, works same as componentQueue.
+type modalQueue struct {
+	mu      sync.RWMutex
+	once    sync.Once
+	closed  bool
+	channel chan *ModalInteraction
+	done    chan struct{}
+}
+
+func (queue *modalQueue) send(itx *ModalInteraction) {
+	queue.mu.RLock()
+	defer queue.mu.RUnlock()
+
+	if queue.closed {
+		return
+	}
+
+	select {
+	case queue.channel <- itx:
+	case <-queue.done:
+	}
+}
+
+// Component handler that runs only for interactions from matching user or guild (zero snowflake matches any).
+type scopedComponent struct {
+	userID  Snowflake
+	guildID Snowflake
+	fn      func(ComponentInteraction)
+}
+
+func (scoped *scopedComponent) matches(itx ComponentInteraction) bool {
+	if !scoped.guildID.IsZero() && scoped.guildID != itx.GuildID {
+		return false
+	}
+
+	if scoped.userID.IsZero() {
+		return true
+	}
+
+	if itx.Member != nil && itx.Member.User != nil {
+		return scoped.userID == itx.Member.User.ID
+	}
+
+	return itx.User != nil && scoped.userID == itx.User.ID
 }
 
 // Makes client dynamically "listen" incoming component type interactions.
 // When component custom id matches - it'll send back interaction through channel.
 // On timeout (min 2s -> max 15min) - client will send <nil> through channel and automatically call close function.
 //
-// Warning! Components handled this way will already be acknowledged.
+// Warning! Components handled this way will already be acknowledged. Custom ids used by static components are rejected
+// and scoped handlers registered for the same custom id receive interaction first. Enable ClientOptions.QueuedComponentsTakePriority
+// to reverse that order - then listener can also temporarily shadow static component with the same custom id.
 func (client *Client) AwaitComponent(customIDs []string, timeout time.Duration) (<-chan *ComponentInteraction, func(), error) {
-	for _, ID := range customIDs {
-		_, exists := client.components[ID]
-		if exists {
-			return nil, nil, errors.New("client already has registered \"" + ID + "\" component as static (custom id already in use)")
+	if !client.queuedComponentsFirst {
+		client.sMu.RLock()
+		for _, ID := range customIDs {
+			_, exists := client.components[ID]
+			if exists {
+				client.sMu.RUnlock()
+				return nil, nil, errors.New("client already has registered \"" + ID + "\" component as static (custom id already in use)")
+			}
 		}
+		client.sMu.RUnlock()
+	}
+
+	queue := &componentQueue{
+		channel: make(chan *ComponentInteraction),
+		done:    make(chan struct{}),
 	}
 
-	signalChannel := make(chan *ComponentInteraction)
 	closeFunction := func() {
-		if signalChannel != nil {
+		queue.once.Do(func() {
+			close(queue.done) // Releases handler blocked on sending before taking queue's lock.
+
 			client.qMu.Lock()
 			for _, key := range customIDs {
-				delete(client.queuedComponents, key)
+				if client.queuedComponents[key] == queue {
+					delete(client.queuedComponents, key)
+				}
 			}
 			client.qMu.Unlock()
 
-			close(signalChannel)
-			signalChannel = nil
-		}
+			queue.mu.Lock()
+			queue.closed = true
+			close(queue.channel)
+			queue.mu.Unlock()
+		})
 	}
 
 	client.qMu.Lock()
 	for _, ID := range customIDs {
-		client.queuedComponents[ID] = signalChannel
+		client.queuedComponents[ID] = queue
 	}
 	client.qMu.Unlock()
 
@@ -79,7 +251,7 @@ func (client *Client) AwaitComponent(customIDs []string, timeout time.Duration)
 	}
 
 	time.AfterFunc(timeout, closeFunction)
-	return signalChannel, closeFunction, nil
+	return queue.channel, closeFunction, nil
 }
 
 // Makes client dynamically "listen" incoming modal type interactions.
@@ -88,24 +260,37 @@ func (client *Client) AwaitComponent(customIDs []string, timeout time.Duration)
 //
 // Warning! Components handled this way will already be acknowledged.
 func (client *Client) AwaitModal(customID string, timeout time.Duration) (<-chan *ModalInteraction, func(), error) {
-	_, exists := client.components[customID]
+	client.sMu.RLock()
+	_, exists := client.modals[customID]
+	client.sMu.RUnlock()
 	if exists {
 		return nil, nil, errors.New("client already has registered \"" + customID + "\" modal as static (custom id already in use)")
 	}
 
-	signalChannel := make(chan *ModalInteraction)
+	queue := &modalQueue{
+		channel: make(chan *ModalInteraction),
+		done:    make(chan struct{}),
+	}
+
 	closeFunction := func() {
-		if signalChannel != nil {
+		queue.once.Do(func() {
+			close(queue.done)
+
 			client.qMu.Lock()
-			delete(client.queuedModals, customID)
+			if client.queuedModals[customID] == queue {
+				delete(client.queuedModals, customID)
+			}
 			client.qMu.Unlock()
-			close(signalChannel)
-			signalChannel = nil
-		}
+
+			queue.mu.Lock()
+			queue.closed = true
+			close(queue.channel)
+			queue.mu.Unlock()
+		})
 	}
 
 	client.qMu.Lock()
-	client.queuedModals[customID] = signalChannel
+	client.queuedModals[customID] = queue
 	client.qMu.Unlock()
 
 	maxTime, minTime := time.Duration(time.Minute*15), time.Duration(time.Second*30)
@@ -116,28 +301,116 @@ func (client *Client) AwaitModal(customID string, timeout time.Duration) (<-chan
 	}
 
 	time.AfterFunc(timeout, closeFunction)
-	return signalChannel, closeFunction, nil
+	return queue.channel, closeFunction, nil
 }
 
 // Starts bot on set route aka "endpoint". Setting example route = "/bot" and address = "192.168.0.7:9070" would make bot work under http://192.168.0.7:9070/bot.
 // Set route as "/" or leave empty string to make it work on any URI (default).
 func (client *Client) ListenAndServe(route string, address string) error {
-	if client.running {
-		return errors.New("client is already running")
+	if _, err := client.prepareServer(route, address); err != nil {
+		return err
 	}
 
-	if route == "" {
-		route = "/"
+	return client.serve((*http.Server).ListenAndServe)
+}
+
+func (client *Client) ListenAndServeTLS(route string, address string, certFile, keyFile string) error {
+	if _, err := client.prepareServer(route, address); err != nil {
+		return err
 	}
 
-	client.running = true
-	http.HandleFunc(route, client.handleRequest)
-	return http.ListenAndServe(address, nil)
+	return client.serve(func(server *http.Server) error {
+		return server.ListenAndServeTLS(certFile, keyFile)
+	})
 }
 
-func (client *Client) ListenAndServeTLS(route string, address string, certFile, keyFile string) error {
+// Works like ListenAndServe but it also listens for SIGTERM & SIGINT signals. On signal it stops accepting new interactions
+// and waits for in-flight ones to finish (up to ClientOptions.ShutdownTimeout). It returns <nil> after clean shutdown.
+func (client *Client) ListenAndServeGraceful(route string, address string) error {
+	if _, err := client.prepareServer(route, address); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- client.serve((*http.Server).ListenAndServe)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), client.shutdownTimeout)
+	defer cancel()
+	return client.Shutdown(shutdownCtx)
+}
+
+// Gracefully stops client's web server - it stops accepting new interactions and waits until in-flight ones are handled or ctx expires.
+// It'll return error if client wasn't started with one of ListenAndServe methods.
+func (client *Client) Shutdown(ctx context.Context) error {
+	client.serverMu.Lock()
+	server := client.server
+	if server != nil {
+		client.stopped = true
+	}
+	client.serverMu.Unlock()
+
+	if server == nil {
+		return errors.New("client's web server is not running")
+	}
+
+	return server.Shutdown(ctx)
+}
+
+// Runs client's web server with provided listen function. When ClientOptions.AutoRestart is enabled,
+// failed server is replaced with fresh one (handler stays registered on http.DefaultServeMux) after ClientOptions.RestartDelay.
+func (client *Client) serve(listen func(server *http.Server) error) error {
+	var restarts uint
+	for {
+		client.serverMu.Lock()
+		server := client.server
+		client.serverMu.Unlock()
+
+		err := listen(server)
+		if !client.autoRestart || errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		if client.maxRestarts != 0 && restarts >= client.maxRestarts {
+			return err
+		}
+
+		restarts++
+		if client.logger != nil {
+			client.logger.Printf("WARN web server stopped error=%q restart_in=%s attempt=%d", err.Error(), client.restartDelay, restarts)
+		}
+
+		time.Sleep(client.restartDelay)
+
+		client.serverMu.Lock()
+		if client.stopped {
+			client.serverMu.Unlock()
+			return http.ErrServerClosed
+		}
+		client.server = &http.Server{Addr: server.Addr}
+		client.serverMu.Unlock()
+	}
+}
+
+func (client *Client) prepareServer(route string, address string) (*http.Server, error) {
+	client.serverMu.Lock()
+	defer client.serverMu.Unlock()
+
 	if client.running {
-		return errors.New("client is already running")
+		return nil, errors.New("client is already running")
 	}
 
 	if route == "" {
@@ -146,7 +419,8 @@ func (client *Client) ListenAndServeTLS(route string, address string, certFile,
 
 	client.running = true
 	http.HandleFunc(route, client.handleRequest)
-	return http.ListenAndServeTLS(address, certFile, keyFile, nil)
+	client.server = &http.Server{Addr: address} // Uses http.DefaultServeMux, same as http.ListenAndServe.
+	return client.server, nil
 }
 
 // Let's you take control over client's life cycle. Please avoid using it unless you want to integrate custom http client.
@@ -161,18 +435,108 @@ func NewClient(options ClientOptions) *Client {
 		panic("failed to decode \"%s\" discord's public key (check if it's correct key)")
 	}
 
+	shutdownTimeout := options.ShutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = DEFAULT_SHUTDOWN_TIMEOUT
+	}
+
+	responseTimeout := options.ResponseTimeout
+	if responseTimeout == 0 {
+		responseTimeout = DEFAULT_RESPONSE_TIMEOUT
+	}
+
+	restartDelay := options.RestartDelay
+	if restartDelay == 0 {
+		restartDelay = DEFAULT_RESTART_DELAY
+	}
+
+	responseMessages := options.ResponseMessages.withDefaults()
+	unknownCommandResponse, err := sonnet.Marshal(ResponseMessage{
+		Type: CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
+		Data: &ResponseMessageData{
+			Content: responseMessages.UnknownCommand,
+			Flags:   EPHEMERAL_MESSAGE_FLAG,
+		},
+	})
+	if err != nil {
+		panic("failed to prepare unknown command response: " + err.Error())
+	}
+
 	return &Client{
 		Rest:                     options.Rest,
 		ApplicationID:            options.ApplicationID,
 		PublicKey:                ed25519.PublicKey(discordPublicKey),
-		commands:                 make(map[string]map[string]Command),
+		commands:                 newCommandRegistry(),
 		components:               make(map[string]func(ComponentInteraction)),
 		modals:                   make(map[string]func(ModalInteraction)),
-		queuedComponents:         make(map[string]chan *ComponentInteraction),
-		queuedModals:             make(map[string]chan *ModalInteraction),
+		queuedComponents:         make(map[string]*componentQueue),
+		queuedModals:             make(map[string]*modalQueue),
+		scopedComponents:         make(map[string][]*scopedComponent),
+		commandStats:             make(map[string]CommandStat),
 		commandMiddlewareHandler: options.CommandMiddleware,
 		componentHandler:         options.ComponentHandler,
 		modalHandler:             options.ModalHandler,
+		unknownCommandHandler:    options.UnknownCommandHandler,
+		onCommandExecuted:        options.OnCommandExecuted,
+		logger:                   options.Logger,
+		debugInteractions:        options.DebugInteractions,
+		autoDefer:                options.AutoDefer,
+		autoDeferDelay:           AUTO_DEFER_DELAY,
+		responseDeadline:         INTERACTION_RESPONSE_DEADLINE,
+		queuedComponentsFirst:    options.QueuedComponentsTakePriority,
+		shutdownTimeout:          shutdownTimeout,
+		timeoutResponse:          options.TimeoutResponse,
+		responseTimeout:          responseTimeout,
+		autoRestart:              options.AutoRestart,
+		restartDelay:             restartDelay,
+		maxRestarts:              options.MaxRestarts,
+		maxUploadSize:            options.MaxUploadSize,
+		responseMessages:         responseMessages,
+		unknownCommandResponse:   unknownCommandResponse,
 		running:                  false,
 	}
 }
+
+// Creates client configured with well-known environment variables: DISCORD_TOKEN (with or without "Bot " prefix), DISCORD_APP_ID and DISCORD_PUBLIC_KEY.
+// All other options use their defaults, see ClientFromEnvWithOptions to customize them.
+// Unlike NewClient, it returns descriptive error (instead of panic) when any variable is missing or malformed.
+func ClientFromEnv() (*Client, error) {
+	return ClientFromEnvWithOptions(ClientOptions{})
+}
+
+// Works like ClientFromEnv but uses provided options for everything else.
+// Environment variables override ApplicationID, PublicKey & Rest from provided options.
+func ClientFromEnvWithOptions(options ClientOptions) (*Client, error) {
+	token := strings.TrimSpace(os.Getenv("DISCORD_TOKEN"))
+	if token == "" {
+		return nil, errors.New("missing DISCORD_TOKEN environment variable")
+	}
+
+	if !strings.HasPrefix(token, "Bot ") {
+		token = "Bot " + token
+	}
+
+	rawAppID := strings.TrimSpace(os.Getenv("DISCORD_APP_ID"))
+	if rawAppID == "" {
+		return nil, errors.New("missing DISCORD_APP_ID environment variable")
+	}
+
+	appID, err := StringToSnowflake(rawAppID)
+	if err != nil || appID.IsZero() {
+		return nil, errors.New("DISCORD_APP_ID environment variable is not valid snowflake: \"" + rawAppID + "\"")
+	}
+
+	publicKey := strings.TrimSpace(os.Getenv("DISCORD_PUBLIC_KEY"))
+	if publicKey == "" {
+		return nil, errors.New("missing DISCORD_PUBLIC_KEY environment variable")
+	}
+
+	if key, err := hex.DecodeString(publicKey); err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("DISCORD_PUBLIC_KEY environment variable is not valid, hex encoded ed25519 public key")
+	}
+
+	options.ApplicationID = appID
+	options.PublicKey = publicKey
+	options.Rest = NewRest(token)
+	return NewClient(options), nil
+}
@@ -0,0 +1,513 @@
+package tempest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Closing queue while handlers are still sending must neither panic nor leave senders blocked.
+func TestAwaitComponentClose(t *testing.T) {
+	client := NewClient(ClientOptions{})
+	signalChannel, closeFunction, err := client.AwaitComponent([]string{"a", "b"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.qMu.RLock()
+			queue, available := client.queuedComponents["a"]
+			client.qMu.RUnlock()
+			if available {
+				queue.send(&ComponentInteraction{})
+			}
+		}()
+	}
+
+	<-signalChannel
+	closeFunction()
+	closeFunction()
+	wg.Wait()
+
+	if _, open := <-signalChannel; open {
+		t.Error("expected channel to be closed")
+	}
+
+	if len(client.queuedComponents) != 0 {
+		t.Error("expected queued components to be removed")
+	}
+}
+
+func TestClientFromEnv(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "XYZABCQEWQ")
+	t.Setenv("DISCORD_APP_ID", "not-a-snowflake")
+	t.Setenv("DISCORD_PUBLIC_KEY", strings.Repeat("ab", ed25519.PublicKeySize))
+
+	if _, err := ClientFromEnv(); err == nil || !strings.Contains(err.Error(), "DISCORD_APP_ID") {
+		t.Errorf("expected DISCORD_APP_ID error, got: %v", err)
+	}
+
+	t.Setenv("DISCORD_APP_ID", "80351110224678912")
+	client, err := ClientFromEnvWithOptions(ClientOptions{DebugInteractions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.ApplicationID != 80351110224678912 || client.Rest.token != "Bot XYZABCQEWQ" || !client.debugInteractions {
+		t.Error("client wasn't configured from environment variables")
+	}
+
+	t.Setenv("DISCORD_PUBLIC_KEY", "abc")
+	if _, err := ClientFromEnv(); err == nil {
+		t.Error("expected error for malformed public key")
+	}
+}
+
+func TestResponseMessages(t *testing.T) {
+	client := NewClient(ClientOptions{
+		ResponseMessages: ResponseMessages{
+			MethodNotAllowed: "metoda niedozwolona",
+			UnknownCommand:   "Nieznana komenda.",
+		},
+	})
+
+	recorder := httptest.NewRecorder()
+	client.handleRequest(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Code != http.StatusMethodNotAllowed || strings.TrimSpace(recorder.Body.String()) != "metoda niedozwolona" {
+		t.Errorf("expected localized 405 response, got: %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	if client.responseMessages.Unauthorized != "unauthorized" {
+		t.Errorf("expected default message for unset field, got: %q", client.responseMessages.Unauthorized)
+	}
+
+	if !strings.Contains(string(client.unknownCommandResponse), `"content":"Nieznana komenda."`) {
+		t.Errorf("expected unknown command response to use custom message, got: %s", client.unknownCommandResponse)
+	}
+}
+
+func TestAutoRestart(t *testing.T) {
+	logs := &bytes.Buffer{}
+	client := NewClient(ClientOptions{
+		Logger:       log.New(logs, "", 0),
+		AutoRestart:  true,
+		RestartDelay: time.Millisecond,
+		MaxRestarts:  2,
+	})
+
+	// Invalid port makes each listen attempt fail immediately.
+	if err := client.ListenAndServe("/auto-restart-test", "127.0.0.1:-1"); err == nil {
+		t.Fatal("expected error after exceeding max restarts")
+	}
+
+	if attempts := strings.Count(logs.String(), "WARN web server stopped"); attempts != 2 {
+		t.Errorf("expected 2 restart attempts, got: %d (%s)", attempts, logs.String())
+	}
+}
+
+func TestCommandStats(t *testing.T) {
+	client := NewClient(ClientOptions{})
+	command := Command{Name: "delete", SlashCommandHandler: func(itx CommandInteraction) {}}
+	itx := CommandInteraction{Data: CommandInteractionData{Name: "tag", Options: []*CommandInteractionOption{
+		{Name: "manage", Type: SUB_COMMAND_GROUP_OPTION_TYPE, Options: []*CommandInteractionOption{
+			{Name: "delete", Type: SUB_OPTION_TYPE},
+		}},
+	}}}
+
+	client.executeCommand(commandPath(itx), command, itx)
+	client.executeCommand(commandPath(itx), command, itx)
+
+	func() {
+		defer func() { recover() }()
+		command.SlashCommandHandler = func(itx CommandInteraction) { panic("oops") }
+		client.executeCommand(commandPath(itx), command, itx)
+	}()
+
+	stat, available := client.CommandStats()["tag manage delete"]
+	if !available || stat.Invocations != 3 || stat.Errors != 1 || stat.LastInvoked.IsZero() {
+		t.Errorf("unexpected command stats: %+v", client.CommandStats())
+	}
+
+	client.ResetCommandStats()
+	if len(client.CommandStats()) != 0 {
+		t.Error("expected stats to be cleared")
+	}
+}
+
+func TestTimeoutResponse(t *testing.T) {
+	if client := NewClient(ClientOptions{}); client.responseTimeout != DEFAULT_RESPONSE_TIMEOUT {
+		t.Errorf("expected default response timeout, got: %s", client.responseTimeout)
+	}
+
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := make(chan string, 2)
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r.Method + " " + r.URL.Path + " " + string(body)
+		if r.Method == http.MethodPatch {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := NewClient(ClientOptions{
+		PublicKey:       hex.EncodeToString(pubkey),
+		Rest:            rest,
+		TimeoutResponse: &ResponseMessageData{Content: "Try again later."},
+		ResponseTimeout: time.Millisecond * 10,
+	})
+
+	client.RegisterCommand(Command{Name: "late", SlashCommandHandler: func(itx CommandInteraction) {
+		select {
+		case request := <-requests:
+			if !strings.HasPrefix(request, "POST /interactions/1/late/callback ") || !strings.Contains(request, `"type":4`) || !strings.Contains(request, `"content":"Try again later."`) {
+				t.Errorf("unexpected timeout response: %s", request)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected timeout response to be sent")
+		}
+
+		if err := itx.SendLinearReply("Pong!", false); err != nil {
+			t.Fatal(err)
+		}
+
+		if request := <-requests; !strings.HasPrefix(request, "PATCH /webhooks/") || !strings.Contains(request, `"content":"Pong!"`) {
+			t.Errorf("expected late reply to edit timeout response, got: %s", request)
+		}
+	}})
+
+	client.RegisterCommand(Command{Name: "quick", SlashCommandHandler: func(itx CommandInteraction) {
+		if err := itx.SendLinearReply("Pong!", false); err != nil {
+			t.Fatal(err)
+		}
+
+		if request := <-requests; !strings.HasPrefix(request, "POST /interactions/2/quick/callback ") || !strings.Contains(request, `"content":"Pong!"`) {
+			t.Errorf("expected handler's own reply to be sent as initial response, got: %s", request)
+		}
+
+		time.Sleep(client.responseTimeout * 5) // Handler is still running, so only its reply can stop timer.
+	}})
+
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"1","type":2,"guild_id":"5","token":"late","data":{"name":"late","type":1}}`))
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"2","type":2,"guild_id":"5","token":"quick","data":{"name":"quick","type":1}}`))
+
+	select {
+	case request := <-requests:
+		t.Errorf("expected timer to be cancelled, got request: %s", request)
+	default:
+	}
+}
+
+func TestHandleRequestTamperedBody(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(ClientOptions{PublicKey: hex.EncodeToString(pubkey)})
+	body := `{"type":1}`
+
+	send := func(payload string) *httptest.ResponseRecorder {
+		request := signedInteractionRequest(privkey, body)
+		request.Body = io.NopCloser(strings.NewReader(payload)) // Keeps signature headers of original body.
+
+		recorder := httptest.NewRecorder()
+		client.handleRequest(recorder, request)
+		return recorder
+	}
+
+	if recorder := send(body); recorder.Code != http.StatusOK {
+		t.Errorf("expected signed ping to be accepted, got: %d", recorder.Code)
+	}
+
+	if recorder := send(`{"type":2}`); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected tampered body with valid signature header to be rejected, got: %d", recorder.Code)
+	}
+}
+
+func TestComponentHandlerPriority(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	click := func(client *Client) {
+		body := `{"id":"1","type":3,"guild_id":"5","token":"abc","data":{"custom_id":"next","component_type":2}}`
+		client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, body))
+	}
+
+	for _, queuedFirst := range []bool{false, true} {
+		client := NewClient(ClientOptions{PublicKey: hex.EncodeToString(pubkey), QueuedComponentsTakePriority: queuedFirst})
+
+		var scoped bool
+		client.RegisterComponentForGuild("next", 5, func(itx ComponentInteraction) { scoped = true })
+
+		signalChannel, closeFunction, err := client.AwaitComponent([]string{"next"}, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan struct{})
+		go func() {
+			click(client)
+			close(done)
+		}()
+
+		var queued bool
+		select {
+		case itx := <-signalChannel:
+			queued = itx != nil
+		case <-time.After(time.Millisecond * 100):
+		}
+		closeFunction()
+		<-done
+
+		if queued != queuedFirst || scoped == queuedFirst {
+			t.Errorf("unexpected handler with QueuedComponentsTakePriority=%t (scoped: %t, queued: %t)", queuedFirst, scoped, queued)
+		}
+	}
+
+	client := NewClient(ClientOptions{QueuedComponentsTakePriority: true})
+	client.RegisterComponent([]string{"next"}, func(itx ComponentInteraction) {})
+	if _, closeFunction, err := client.AwaitComponent([]string{"next"}, time.Minute); err != nil {
+		t.Errorf("expected queued listener to be allowed to shadow static component, got: %s", err)
+	} else {
+		closeFunction()
+	}
+}
+
+func TestGuildSettings(t *testing.T) {
+	client := NewClient(ClientOptions{})
+	client.SetGuildSetting(1, "prefix", "!")
+	client.SetGuildSetting(2, "prefix", "?")
+
+	if value, available := client.GetGuildSetting(1, "prefix"); !available || value != "!" {
+		t.Errorf("expected guild's own setting, got: %v", value)
+	}
+
+	client.DeleteGuildSetting(1, "prefix")
+	if _, available := client.GetGuildSetting(1, "prefix"); available {
+		t.Error("expected setting to be deleted")
+	}
+
+	if value, _ := client.GetGuildSetting(2, "prefix"); value != "?" {
+		t.Errorf("expected other guild's setting to stay untouched, got: %v", value)
+	}
+}
+
+// Creates rest bound to test server that answers all requests with provided handler. Server is closed after test finishes.
+func newTestRest(t *testing.T, handler http.HandlerFunc) *Rest {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	rest := NewCustomRest("Bot test", ts.Client())
+	rest.BaseURL = ts.URL
+	return rest
+}
+
+// Creates interaction request signed with provided key, same way Discord signs its requests.
+func signedInteractionRequest(privkey ed25519.PrivateKey, body string) *http.Request {
+	timestamp := "1608597133"
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	request.Header.Set("X-Signature-Timestamp", timestamp)
+	request.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privkey, []byte(timestamp+body))))
+	return request
+}
+
+func TestMiddlewareRejectionSkipsClientResponses(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callbacks := make(chan string, 2)
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		callbacks <- r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := NewClient(ClientOptions{
+		PublicKey:         hex.EncodeToString(pubkey),
+		Rest:              rest,
+		AutoDefer:         true,
+		TimeoutResponse:   &ResponseMessageData{Content: "Try again later."},
+		ResponseTimeout:   time.Millisecond * 10,
+		CommandMiddleware: func(itx CommandInteraction) bool { return false },
+	})
+	client.autoDeferDelay = time.Millisecond * 5
+
+	var executed bool
+	if err := client.RegisterCommand(Command{Name: "ping", SlashCommandHandler: func(itx CommandInteraction) { executed = true }}); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	client.handleRequest(recorder, signedInteractionRequest(privkey, `{"id":"1","type":2,"guild_id":"5","token":"abc","data":{"name":"ping","type":1}}`))
+	if recorder.Code != http.StatusNoContent || executed {
+		t.Fatalf("expected command to be dropped by middleware (status: %d, executed: %t)", recorder.Code, executed)
+	}
+
+	select {
+	case route := <-callbacks:
+		t.Errorf("expected no callback requests for rejected command, got: %s", route)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestAutoDeferOnlyWhileHandlerRuns(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callbacks := make(chan string, 2)
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		callbacks <- r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := NewClient(ClientOptions{PublicKey: hex.EncodeToString(pubkey), Rest: rest, AutoDefer: true})
+	client.autoDeferDelay = time.Millisecond * 5
+
+	if err := client.RegisterCommand(Command{Name: "quiet", SlashCommandHandler: func(itx CommandInteraction) {}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.RegisterCommand(Command{Name: "slow", SlashCommandHandler: func(itx CommandInteraction) {
+		select {
+		case route := <-callbacks:
+			if route != "/interactions/2/slow/callback" {
+				t.Errorf("unexpected callback request: %s", route)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected running handler to be deferred automatically")
+		}
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"1","type":2,"guild_id":"5","token":"quiet","data":{"name":"quiet","type":1}}`))
+	select {
+	case route := <-callbacks:
+		t.Errorf("expected no deferral after handler returned, got: %s", route)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"2","type":2,"guild_id":"5","token":"slow","data":{"name":"slow","type":1}}`))
+}
+
+func TestFailedAutoDeferKeepsTimeoutResponse(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attempts int32
+	callbacks := make(chan string, 2)
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			http.Error(w, `{"message": "Unknown interaction", "code": 10062}`, http.StatusNotFound)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		callbacks <- string(body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := NewClient(ClientOptions{
+		PublicKey:       hex.EncodeToString(pubkey),
+		Rest:            rest,
+		AutoDefer:       true,
+		TimeoutResponse: &ResponseMessageData{Content: "Try again later."},
+		ResponseTimeout: time.Millisecond * 50,
+	})
+	client.autoDeferDelay = time.Millisecond * 5
+
+	if err := client.RegisterCommand(Command{Name: "slow", SlashCommandHandler: func(itx CommandInteraction) {
+		select {
+		case body := <-callbacks:
+			if !strings.Contains(body, `"content":"Try again later."`) {
+				t.Errorf("expected timeout response after failed deferral, got: %s", body)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected timeout response to be sent after failed deferral")
+		}
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"1","type":2,"guild_id":"5","token":"abc","data":{"name":"slow","type":1}}`))
+}
+
+func TestInteractionDoneWithAutoDefer(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callbacks := make(chan string, 1)
+	var edits int32
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			atomic.AddInt32(&edits, 1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		callbacks <- r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := NewClient(ClientOptions{PublicKey: hex.EncodeToString(pubkey), Rest: rest, AutoDefer: true})
+	client.autoDeferDelay, client.responseDeadline = time.Millisecond*5, time.Millisecond*20
+
+	if err := client.RegisterCommand(Command{Name: "slow", SlashCommandHandler: func(itx CommandInteraction) {
+		done := itx.Done()
+
+		select {
+		case <-callbacks:
+		case <-time.After(time.Second):
+			t.Fatal("expected command to be deferred automatically")
+		}
+
+		select {
+		case <-done:
+			t.Fatal("expected channel to stay open after automatic deferral (handler can still reply)")
+		case <-time.After(client.responseDeadline * 5):
+		}
+
+		if err := itx.SendLinearReply("Done!", false); err != nil {
+			t.Fatal(err)
+		}
+
+		if count := atomic.LoadInt32(&edits); count != 1 {
+			t.Errorf("expected reply to edit deferred response, got %d edits", count)
+		}
+
+		select {
+		case <-done:
+		default:
+			t.Error("expected channel to close after handler's reply")
+		}
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.handleRequest(httptest.NewRecorder(), signedInteractionRequest(privkey, `{"id":"1","type":2,"guild_id":"5","token":"abc","data":{"name":"slow","type":1}}`))
+}
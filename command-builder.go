@@ -0,0 +1,166 @@
+package tempest
+
+// Fluent alternative to manually initializing Command struct. Create it with NewCommand function.
+// Methods like WithChoices or WithAutoComplete always apply to most recently added option.
+//
+//	builder := tempest.NewCommand("avatar", "Shows user's avatar.").
+//		AddUserOption("user", "User to show avatar of.", false).
+//		WithHandler(avatarHandler)
+//
+//	client.RegisterCommandBuilder(builder)
+type CommandBuilder struct {
+	command     Command
+	subCommands []*CommandBuilder
+}
+
+func NewCommand(name string, description string) *CommandBuilder {
+	return &CommandBuilder{
+		command: Command{
+			Type:        CHAT_INPUT_COMMAND_TYPE,
+			Name:        name,
+			Description: description,
+		},
+	}
+}
+
+func (builder *CommandBuilder) AddStringOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(STRING_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddIntegerOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(INTEGER_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddNumberOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(NUMBER_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddBoolOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(BOOLEAN_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddUserOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(USER_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddChannelOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(CHANNEL_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddRoleOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(ROLE_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddMentionableOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(MENTIONABLE_OPTION_TYPE, name, description, required)
+}
+
+func (builder *CommandBuilder) AddAttachmentOption(name string, description string, required bool) *CommandBuilder {
+	return builder.addOption(ATTACHMENT_OPTION_TYPE, name, description, required)
+}
+
+// Sets list of predefined choices for most recently added option. It does nothing if there's no option yet.
+func (builder *CommandBuilder) WithChoices(choices ...Choice) *CommandBuilder {
+	if option := builder.lastOption(); option != nil {
+		option.Choices = append(option.Choices, choices...)
+	}
+	return builder
+}
+
+// Marks most recently added option to be handled by command's auto complete handler. It does nothing if there's no option yet.
+func (builder *CommandBuilder) WithAutoComplete() *CommandBuilder {
+	if option := builder.lastOption(); option != nil {
+		option.AutoComplete = true
+	}
+	return builder
+}
+
+// Restricts most recently added channel option to listed channel types. It does nothing if there's no option yet.
+func (builder *CommandBuilder) WithChannelTypes(types ...ChannelType) *CommandBuilder {
+	if option := builder.lastOption(); option != nil {
+		option.ChannelTypes = append(option.ChannelTypes, types...)
+	}
+	return builder
+}
+
+// Sets range of allowed values for most recently added integer or number option. It does nothing if there's no option yet.
+// Discord validates it before app receives interaction.
+func (builder *CommandBuilder) WithValueRange(min float64, max float64) *CommandBuilder {
+	if option := builder.lastOption(); option != nil {
+		option.MinValue, option.MaxValue = &min, &max
+	}
+	return builder
+}
+
+// Sets range of allowed lengths for most recently added string option. It does nothing if there's no option yet.
+func (builder *CommandBuilder) WithLengthRange(min uint, max uint) *CommandBuilder {
+	if option := builder.lastOption(); option != nil {
+		option.MinLength, option.MaxLength = min, max
+	}
+	return builder
+}
+
+func (builder *CommandBuilder) WithHandler(fn func(itx CommandInteraction)) *CommandBuilder {
+	builder.command.SlashCommandHandler = fn
+	return builder
+}
+
+func (builder *CommandBuilder) WithAutoCompleteHandler(fn func(itx AutoCompleteInteraction) []Choice) *CommandBuilder {
+	builder.command.AutoCompleteHandler = fn
+	return builder
+}
+
+// Adds subcommand, use provided function to define its options and handlers.
+// Remember that Discord doesn't allow to use root command (the one with subcommands) directly.
+func (builder *CommandBuilder) AddSubCommand(name string, description string, fn func(*CommandBuilder)) *CommandBuilder {
+	subBuilder := NewCommand(name, description)
+	if fn != nil {
+		fn(subBuilder)
+	}
+
+	builder.subCommands = append(builder.subCommands, subBuilder)
+	return builder
+}
+
+// Returns built command. It panics if builder has subcommands, because registering its result with Client.RegisterCommand
+// would silently skip all of them - use Client.RegisterCommandBuilder for such commands instead.
+func (builder *CommandBuilder) Build() Command {
+	if len(builder.subCommands) != 0 {
+		panic("command \"" + builder.command.Name + "\" has subcommands (use Client.RegisterCommandBuilder to register it)")
+	}
+	return builder.buildRoot()
+}
+
+// Returns root command without its subcommands, they're kept separately (see BuildSubCommands) because that's how they're stored in client's registry.
+func (builder *CommandBuilder) buildRoot() Command {
+	command := builder.command
+	command.Options = make([]CommandOption, len(builder.command.Options))
+	copy(command.Options, builder.command.Options)
+	return command
+}
+
+// Returns all subcommands defined with AddSubCommand, ready to use with Client.RegisterSubCommand.
+func (builder *CommandBuilder) BuildSubCommands() []Command {
+	list := make([]Command, len(builder.subCommands))
+	for i, subBuilder := range builder.subCommands {
+		list[i] = subBuilder.Build()
+	}
+	return list
+}
+
+func (builder *CommandBuilder) addOption(optionType OptionType, name string, description string, required bool) *CommandBuilder {
+	builder.command.Options = append(builder.command.Options, CommandOption{
+		Type:        optionType,
+		Name:        name,
+		Description: description,
+		Required:    required,
+	})
+	return builder
+}
+
+func (builder *CommandBuilder) lastOption() *CommandOption {
+	if len(builder.command.Options) == 0 {
+		return nil
+	}
+	return &builder.command.Options[len(builder.command.Options)-1]
+}
@@ -0,0 +1,85 @@
+package tempest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+func TestCommandBuilder(t *testing.T) {
+	builder := NewCommand("tag", "Manages tags.").
+		AddStringOption("name", "Tag name.", true).
+		WithAutoComplete().
+		AddIntegerOption("limit", "Max results.", false).
+		WithChoices(Choice{Name: "Ten", Value: 10}, Choice{Name: "Twenty", Value: 20}).
+		AddSubCommand("create", "Creates tag.", func(sub *CommandBuilder) {
+			sub.AddStringOption("content", "Tag content.", true)
+		})
+
+	command := builder.buildRoot()
+	if command.Type != CHAT_INPUT_COMMAND_TYPE || command.Name != "tag" {
+		t.Fatalf("unexpected root command: %+v", command)
+	}
+
+	if len(command.Options) != 2 {
+		t.Fatalf("expected 2 options, got %d", len(command.Options))
+	}
+
+	if !command.Options[0].AutoComplete || !command.Options[0].Required || command.Options[0].Type != STRING_OPTION_TYPE {
+		t.Errorf("unexpected first option: %+v", command.Options[0])
+	}
+
+	if command.Options[1].AutoComplete || len(command.Options[1].Choices) != 2 {
+		t.Errorf("unexpected second option: %+v", command.Options[1])
+	}
+
+	subCommands := builder.BuildSubCommands()
+	if len(subCommands) != 1 || subCommands[0].Name != "create" || len(subCommands[0].Options) != 1 {
+		t.Fatalf("unexpected subcommands: %+v", subCommands)
+	}
+
+	client := Client{commands: newCommandRegistry()}
+	if err := client.RegisterCommandBuilder(builder); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, available := client.commands.chatInput["tag"]["create"]; !available {
+		t.Error("expected subcommand to be registered")
+	}
+}
+
+func TestCommandBuilderBuildWithSubCommandsPanics(t *testing.T) {
+	builder := NewCommand("tag", "Manages tags.").
+		AddSubCommand("create", "Creates tag.", nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Build to panic for command with subcommands")
+		}
+	}()
+
+	builder.Build()
+}
+
+func TestCommandBuilderConstraints(t *testing.T) {
+	command := NewCommand("roll", "Rolls dice.").
+		AddIntegerOption("offset", "Value added to result.", false).
+		WithValueRange(0, 100).
+		AddStringOption("label", "Roll label.", false).
+		WithLengthRange(1, 32).
+		AddChannelOption("channel", "Channel to post result in.", false).
+		WithChannelTypes(GUILD_TEXT_CHANNEL_TYPE, GUILD_ANNOUNCEMENT_CHANNEL_TYPE).
+		Build()
+
+	body, err := sonnet.Marshal(command)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fragment := range []string{`"min_value":0`, `"max_value":100`, `"min_length":1`, `"max_length":32`, `"channel_types":[0,5]`} {
+		if !strings.Contains(string(body), fragment) {
+			t.Errorf("expected %s in command payload, got: %s", fragment, body)
+		}
+	}
+}
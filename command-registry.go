@@ -0,0 +1,83 @@
+package tempest
+
+import "errors"
+
+// Client's command registry. Discord requires command names to be unique only within single command type (and scope),
+// so slash, user & message commands are kept in separate maps. Each root command is stored as tree of its subcommands
+// (root itself is stored under ROOT_PLACEHOLDER key). Caller has to hold client's sMu lock.
+type commandRegistry struct {
+	chatInput map[string]map[string]Command
+	user      map[string]map[string]Command
+	message   map[string]map[string]Command
+}
+
+func newCommandRegistry() *commandRegistry {
+	return &commandRegistry{
+		chatInput: make(map[string]map[string]Command),
+		user:      make(map[string]map[string]Command),
+		message:   make(map[string]map[string]Command),
+	}
+}
+
+// Returns map holding commands of given type (<nil> for unknown types). Zero type is treated as CHAT_INPUT_COMMAND_TYPE.
+func (registry *commandRegistry) trees(commandType CommandType) map[string]map[string]Command {
+	switch commandType {
+	case 0, CHAT_INPUT_COMMAND_TYPE:
+		return registry.chatInput
+	case USER_COMMAND_TYPE:
+		return registry.user
+	case MESSAGE_COMMAND_TYPE:
+		return registry.message
+	}
+	return nil
+}
+
+// Adds root command to registry. It fails when command with the same name & type is already registered.
+func (registry *commandRegistry) register(command Command) error {
+	if command.Type == 0 {
+		command.Type = CHAT_INPUT_COMMAND_TYPE
+	}
+
+	trees := registry.trees(command.Type)
+	if trees == nil {
+		return errors.New("cannot register \"" + command.Name + "\" command of unknown (" + command.Type.String() + ") type")
+	}
+
+	if _, exists := trees[command.Name]; exists {
+		return errors.New("client already has registered \"" + command.Name + "\" " + commandKind(command.Type) + " command (name already in use)")
+	}
+
+	trees[command.Name] = map[string]Command{ROOT_PLACEHOLDER: command}
+	return nil
+}
+
+// Removes root command of any type with given name. Returns false if there was none.
+func (registry *commandRegistry) remove(name string) bool {
+	removed := false
+	for _, trees := range registry.all() {
+		if _, exists := trees[name]; exists {
+			delete(trees, name)
+			removed = true
+		}
+	}
+	return removed
+}
+
+func (registry *commandRegistry) all() []map[string]map[string]Command {
+	return []map[string]map[string]Command{registry.chatInput, registry.user, registry.message}
+}
+
+// Returns total number of registered root commands.
+func (registry *commandRegistry) count() int {
+	return len(registry.chatInput) + len(registry.user) + len(registry.message)
+}
+
+func commandKind(commandType CommandType) string {
+	switch commandType {
+	case USER_COMMAND_TYPE:
+		return "user"
+	case MESSAGE_COMMAND_TYPE:
+		return "message"
+	}
+	return "slash"
+}
@@ -1,5 +1,12 @@
 package tempest
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-types
 type CommandType uint8
 
@@ -9,12 +16,24 @@ const (
 	MESSAGE_COMMAND_TYPE                           // Mounted to text message.
 )
 
+func (ct CommandType) String() string {
+	switch ct {
+	case CHAT_INPUT_COMMAND_TYPE:
+		return "CHAT_INPUT"
+	case USER_COMMAND_TYPE:
+		return "USER"
+	case MESSAGE_COMMAND_TYPE:
+		return "MESSAGE"
+	}
+	return "UNKNOWN"
+}
+
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-type
 type OptionType uint8
 
 const (
 	SUB_OPTION_TYPE OptionType = iota + 1
-	_                          // OPTION_SUB_COMMAND_GROUP (not supported)
+	SUB_COMMAND_GROUP_OPTION_TYPE
 	STRING_OPTION_TYPE
 	INTEGER_OPTION_TYPE
 	BOOLEAN_OPTION_TYPE
@@ -26,6 +45,34 @@ const (
 	ATTACHMENT_OPTION_TYPE
 )
 
+func (ot OptionType) String() string {
+	switch ot {
+	case SUB_OPTION_TYPE:
+		return "SUB_COMMAND"
+	case SUB_COMMAND_GROUP_OPTION_TYPE:
+		return "SUB_COMMAND_GROUP"
+	case STRING_OPTION_TYPE:
+		return "STRING"
+	case INTEGER_OPTION_TYPE:
+		return "INTEGER"
+	case BOOLEAN_OPTION_TYPE:
+		return "BOOLEAN"
+	case USER_OPTION_TYPE:
+		return "USER"
+	case CHANNEL_OPTION_TYPE:
+		return "CHANNEL"
+	case ROLE_OPTION_TYPE:
+		return "ROLE"
+	case MENTIONABLE_OPTION_TYPE:
+		return "MENTIONABLE"
+	case NUMBER_OPTION_TYPE:
+		return "NUMBER"
+	case ATTACHMENT_OPTION_TYPE:
+		return "ATTACHMENT"
+	}
+	return "UNKNOWN"
+}
+
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-structure
 type Command struct {
 	ID                       Snowflake         `json:"-"` // Omit in json parsing for now because it was breaking Client#commandParse.
@@ -46,6 +93,152 @@ type Command struct {
 	SlashCommandHandler func(itx CommandInteraction)               `json:"-"` // Custom handler for slash command interactions. It's a Tempest specific field. Warning! Library will panic if command can be triggered but doesn't have this handler.
 }
 
+// Returns sha256 hash (hex encoded) of command's definition. Only fields that app can set are hashed and they're normalized first
+// (empty localizations, missing command type, integer vs float choice values), so command fetched from API, where Discord fills
+// in its own defaults, hashes the same as matching local command. Fields assigned by Discord (id, application id, guild id & version)
+// are ignored. So is dm_permission - Tempest never sends it as false, meaning Discord always stores its default (true).
+func (command Command) CommandHash() string {
+	commandType := command.Type
+	if commandType == 0 {
+		commandType = CHAT_INPUT_COMMAND_TYPE
+	}
+
+	body, err := sonnet.Marshal(commandHashProjection{
+		Type:                     commandType,
+		Name:                     command.Name,
+		NameLocalizations:        normalizeLocalizations(command.NameLocalizations),
+		Description:              command.Description,
+		DescriptionLocalizations: normalizeLocalizations(command.DescriptionLocalizations),
+		Options:                  projectOptions(command.Options),
+		DefaultMemberPermissions: command.DefaultMemberPermissions,
+		NSFW:                     command.NSFW,
+	})
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// Normalized view of command used by Command.CommandHash.
+type commandHashProjection struct {
+	Type                     CommandType            `json:"type"`
+	Name                     string                 `json:"name"`
+	NameLocalizations        map[string]string      `json:"name_localizations"`
+	Description              string                 `json:"description"`
+	DescriptionLocalizations map[string]string      `json:"description_localizations"`
+	Options                  []optionHashProjection `json:"options"`
+	DefaultMemberPermissions uint64                 `json:"default_member_permissions"`
+	NSFW                     bool                   `json:"nsfw"`
+}
+
+type optionHashProjection struct {
+	Type                     OptionType             `json:"type"`
+	Name                     string                 `json:"name"`
+	NameLocalizations        map[string]string      `json:"name_localizations"`
+	Description              string                 `json:"description"`
+	DescriptionLocalizations map[string]string      `json:"description_localizations"`
+	Required                 bool                   `json:"required"`
+	MinValue                 *float64               `json:"min_value"`
+	MaxValue                 *float64               `json:"max_value"`
+	MinLength                uint                   `json:"min_length"`
+	MaxLength                uint                   `json:"max_length"`
+	Options                  []optionHashProjection `json:"options"`
+	ChannelTypes             []ChannelType          `json:"channel_types"`
+	Choices                  []choiceHashProjection `json:"choices"`
+	AutoComplete             bool                   `json:"autocomplete"`
+}
+
+type choiceHashProjection struct {
+	Name              string            `json:"name"`
+	NameLocalizations map[string]string `json:"name_localizations"`
+	Value             any               `json:"value"`
+}
+
+func projectOptions(options []CommandOption) []optionHashProjection {
+	if len(options) == 0 {
+		return nil
+	}
+
+	list := make([]optionHashProjection, len(options))
+	for i, option := range options {
+		var choices []choiceHashProjection
+		for _, choice := range option.Choices {
+			choices = append(choices, choiceHashProjection{
+				Name:              choice.Name,
+				NameLocalizations: normalizeLocalizations(choice.NameLocalizations),
+				Value:             normalizeChoiceValue(choice.Value),
+			})
+		}
+
+		var channelTypes []ChannelType
+		if len(option.ChannelTypes) != 0 {
+			channelTypes = option.ChannelTypes
+		}
+
+		list[i] = optionHashProjection{
+			Type:                     option.Type,
+			Name:                     option.Name,
+			NameLocalizations:        normalizeLocalizations(option.NameLocalizations),
+			Description:              option.Description,
+			DescriptionLocalizations: normalizeLocalizations(option.DescriptionLocalizations),
+			Required:                 option.Required,
+			MinValue:                 option.MinValue,
+			MaxValue:                 option.MaxValue,
+			MinLength:                option.MinLength,
+			MaxLength:                option.MaxLength,
+			Options:                  projectOptions(option.Options),
+			ChannelTypes:             channelTypes,
+			Choices:                  choices,
+			AutoComplete:             option.AutoComplete,
+		}
+	}
+	return list
+}
+
+// Discord returns null (or skips field) for commands without localizations.
+func normalizeLocalizations(localizations map[string]string) map[string]string {
+	if len(localizations) == 0 {
+		return nil
+	}
+	return localizations
+}
+
+// Choices parsed from API always carry numbers as float64 while local ones often use ints.
+func normalizeChoiceValue(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+// Used only for partial JSON parsing (Command struct omits its id in JSON).
+type commandIDExtractor struct {
+	ID Snowflake `json:"id"`
+}
+
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-structure
 type CommandOption struct {
 	Type                     OptionType        `json:"type"`
@@ -54,12 +247,12 @@ type CommandOption struct {
 	Description              string            `json:"description"`
 	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
 	Required                 bool              `json:"required,omitempty"`
-	MinValue                 float64           `json:"min_value,omitempty"`
-	MaxValue                 float64           `json:"max_value,omitempty"`
-	MinLength                uint              `json:"min_length,omitempty"`
-	MaxLength                uint              `json:"max_length,omitempty"`
+	MinValue                 *float64          `json:"min_value,omitempty"`  // Smallest value allowed for integer & number options. Pointer, so 0 can be used as limit too.
+	MaxValue                 *float64          `json:"max_value,omitempty"`  // Largest value allowed for integer & number options.
+	MinLength                uint              `json:"min_length,omitempty"` // Min length of string option's value (0-6000).
+	MaxLength                uint              `json:"max_length,omitempty"` // Max length of string option's value (1-6000).
 	Options                  []CommandOption   `json:"options,omitempty"`
-	ChannelTypes             []ChannelType     `json:"channel_types,omitempty"`
+	ChannelTypes             []ChannelType     `json:"channel_types,omitempty"` // Restricts channel option to listed channel types (all types are allowed when empty).
 	Choices                  []Choice          `json:"choices,omitempty"`
 	AutoComplete             bool              `json:"autocomplete,omitempty"` // Required to be = true if you want to catch it later in auto complete handler.
 }
@@ -32,6 +32,28 @@ const (
 	CHANNEL_SELECT_COMPONENT_TYPE
 )
 
+func (ct ComponentType) String() string {
+	switch ct {
+	case ROW_COMPONENT_TYPE:
+		return "ACTION_ROW"
+	case BUTTON_COMPONENT_TYPE:
+		return "BUTTON"
+	case SELECT_MENU_COMPONENT_TYPE:
+		return "STRING_SELECT"
+	case TEXT_INPUT_COMPONENT_TYPE:
+		return "TEXT_INPUT"
+	case USER_SELECT_COMPONENT_TYPE:
+		return "USER_SELECT"
+	case ROLE_SELECT_COMPONENT_TYPE:
+		return "ROLE_SELECT"
+	case MENTIONABLE_SELECT_COMPONENT_TYPE:
+		return "MENTIONABLE_SELECT"
+	case CHANNEL_SELECT_COMPONENT_TYPE:
+		return "CHANNEL_SELECT"
+	}
+	return "UNKNOWN"
+}
+
 // https://discord.com/developers/docs/interactions/message-components#text-inputs-text-input-styles
 type TextInputStyle uint8
 
@@ -78,3 +100,75 @@ type ComponentRow struct {
 	Type       ComponentType `json:"type"` // Always 1
 	Components []*Component  `json:"components"`
 }
+
+// Whether row's content stays within Discord's limits.
+func (row ComponentRow) fits() bool {
+	if len(row.Components) > MAX_ROW_BUTTONS {
+		return false
+	}
+
+	for _, component := range row.Components {
+		if component != nil && component.Type != BUTTON_COMPONENT_TYPE && len(row.Components) > 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Helps building action rows that respect Discord's limits (up to 5 buttons or a single select menu per row).
+// It's recommended to use builder over raw ComponentRow struct as overflowing rows would otherwise only fail on Discord's side.
+type ActionRowBuilder struct {
+	components []*Component
+}
+
+func NewActionRow() *ActionRowBuilder {
+	return &ActionRowBuilder{
+		components: make([]*Component, 0, MAX_ROW_BUTTONS),
+	}
+}
+
+// Appends button to the row. It'll return ErrRowFull if row already has 5 buttons or a select menu.
+func (builder *ActionRowBuilder) AddButton(button Component) error {
+	if len(builder.components) >= MAX_ROW_BUTTONS || builder.hasSelectMenu() {
+		return ErrRowFull
+	}
+
+	button.Type = BUTTON_COMPONENT_TYPE
+	builder.components = append(builder.components, &button)
+	return nil
+}
+
+// Sets select menu as row's only component. It'll return ErrRowFull if row already has any component.
+// Menu type defaults to SELECT_MENU_COMPONENT_TYPE (string select) when not provided.
+func (builder *ActionRowBuilder) AddSelectMenu(menu Component) error {
+	if len(builder.components) != 0 {
+		return ErrRowFull
+	}
+
+	if menu.Type == 0 {
+		menu.Type = SELECT_MENU_COMPONENT_TYPE
+	}
+
+	builder.components = append(builder.components, &menu)
+	return nil
+}
+
+func (builder *ActionRowBuilder) Build() *ComponentRow {
+	components := make([]*Component, len(builder.components))
+	copy(components, builder.components)
+
+	return &ComponentRow{
+		Type:       ROW_COMPONENT_TYPE,
+		Components: components,
+	}
+}
+
+func (builder *ActionRowBuilder) hasSelectMenu() bool {
+	for _, component := range builder.components {
+		if component.Type != BUTTON_COMPONENT_TYPE {
+			return true
+		}
+	}
+	return false
+}
@@ -1,20 +1,76 @@
 package tempest
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
-	DISCORD_API_URL  = "https://discord.com/api/v10"
+	DISCORD_API_URL  = DISCORD_API_ROOT_URL + "/v" + DISCORD_API_VERSION
 	DISCORD_CDN_URL  = "https://cdn.discordapp.com"
 	USER_AGENT       = "DiscordApp https://github.com/Amatsagu/tempest"
 	EPOCH            = 1420070400000 // Discord epoch in milliseconds
 	ROOT_PLACEHOLDER = "-"
+
+	DISCORD_API_ROOT_URL = "https://discord.com/api" // Unversioned Discord API url (see Rest.APIVersion).
+	DISCORD_API_VERSION  = "10"                      // Default Discord API version used by Rest.
+
+	DEFAULT_SYNC_PARALLELISM = 5 // Default number of guilds synced at the same time (see SyncOptions).
+)
+
+const (
+	DEFAULT_REST_TIMEOUT          = time.Second * 30        // Default time limit for single http request made by Rest.
+	DEFAULT_RATE_LIMIT_BUFFER     = time.Millisecond * 100  // Default extra wait time on top of Discord's retry_after.
+	REST_MAX_ATTEMPTS             = 3                       // Max number of attempts Rest makes for single request (see Rest.RetryableStatusCodes).
+	REST_RETRY_DELAY              = time.Millisecond * 250  // Base wait time before retrying failed request, multiplied by number of failed attempts.
+	AUTO_DEFER_DELAY              = time.Millisecond * 2500 // Time after which client defers unacknowledged command interaction (Discord requires response within 3s).
+	INTERACTION_RESPONSE_DEADLINE = time.Millisecond * 2900 // Time after which CommandInteraction.Done closes when interaction got no response.
+	INTERACTION_TOKEN_LIFETIME    = time.Minute * 15        // Time for which interaction token can be used to edit reply & send follow ups.
+	DEFAULT_REST_CONCURRENCY      = 10                      // Default max number of requests in-flight at the same time.
+	DEFAULT_SHUTDOWN_TIMEOUT      = time.Second * 10        // Default time ListenAndServeGraceful waits for in-flight interactions.
+	DEFAULT_MAX_RESPONSE_SIZE     = 10 << 20                // Default max size of response body read by Rest (10 MiB).
+	DEFAULT_RESPONSE_TIMEOUT      = time.Millisecond * 2900 // Default time after which client sends ClientOptions.TimeoutResponse.
+	DEFAULT_RESTART_DELAY         = time.Second * 5         // Default wait time before client restarts failed web server (see ClientOptions.AutoRestart).
+)
+
+// https://discord.com/developers/docs/resources/channel#create-message
+const (
+	MAX_MESSAGE_CONTENT_LENGTH   = 2000
+	MAX_EMBED_DESCRIPTION_LENGTH = 4096
+	MAX_EMBEDS_CHARACTER_COUNT   = 6000 // Sum of all embed text fields in a single message.
+	MAX_EMBED_FIELDS             = 25
+	MAX_EMBED_URL_LENGTH         = 2048
+)
+
+// https://discord.com/developers/docs/reference#uploading-files
+const (
+	MAX_MESSAGE_FILES       = 10
+	MAX_UPLOAD_SIZE         = 8 << 20  // Default upload limit (8 MiB), shared by all files attached to single message.
+	MAX_BOOSTED_UPLOAD_SIZE = 50 << 20 // Upload limit in guilds with higher boost level (50 MiB).
+)
+
+// https://discord.com/developers/docs/resources/channel#create-channel-invite
+const MAX_INVITE_AGE = 604800 // In seconds (7 days).
+
+// https://discord.com/developers/docs/resources/guild#list-guild-members
+const MAX_MEMBERS_PAGE_SIZE = 1000
+
+// https://discord.com/developers/docs/resources/guild#get-guild-bans
+const MAX_BANS_PAGE_SIZE = 1000
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-autocomplete
+const MAX_AUTO_COMPLETE_CHOICES = 25
+
+// https://discord.com/developers/docs/interactions/message-components#action-rows
+const (
+	MAX_MESSAGE_COMPONENT_ROWS = 5
+	MAX_ROW_BUTTONS            = 5 // Row can hold either up to 5 buttons or a single select menu.
 )
 
 // Prepare those replies as they never change so there's no point in re-creating them each time.
 var (
-	private_PING_RESPONSE_RAW_BODY            = []byte(fmt.Sprintf(`{"type":%d}`, PONG_RESPONSE_TYPE))
-	private_ACKNOWLEDGE_RESPONSE_RAW_BODY     = []byte(fmt.Sprintf(`{"type":%d}`, DEFERRED_UPDATE_MESSAGE_RESPONSE_TYPE))
-	private_UNKNOWN_COMMAND_RESPONSE_RAW_BODY = []byte(fmt.Sprintf(`{"type":%d,"data":{"content":"Oh snap! It looks like you tried to trigger (/) unknown command. Please report this bug to bot owner.","flags":64}}`, CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE))
+	private_PING_RESPONSE_RAW_BODY        = []byte(fmt.Sprintf(`{"type":%d}`, PONG_RESPONSE_TYPE))
+	private_ACKNOWLEDGE_RESPONSE_RAW_BODY = []byte(fmt.Sprintf(`{"type":%d}`, DEFERRED_UPDATE_MESSAGE_RESPONSE_TYPE))
 )
 
 // Those are used to replace seemingly empty slice into empty array after marsalling struct to json string.
@@ -0,0 +1,94 @@
+package tempest
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// https://discord.com/developers/docs/monetization/entitlements#entitlement-object-entitlement-types
+type EntitlementType uint8
+
+const (
+	PURCHASE_ENTITLEMENT_TYPE EntitlementType = iota + 1
+	PREMIUM_SUBSCRIPTION_ENTITLEMENT_TYPE
+	DEVELOPER_GIFT_ENTITLEMENT_TYPE
+	TEST_MODE_PURCHASE_ENTITLEMENT_TYPE
+	FREE_PURCHASE_ENTITLEMENT_TYPE
+	USER_GIFT_ENTITLEMENT_TYPE
+	PREMIUM_PURCHASE_ENTITLEMENT_TYPE
+	APPLICATION_SUBSCRIPTION_ENTITLEMENT_TYPE
+)
+
+// https://discord.com/developers/docs/monetization/entitlements#create-test-entitlement-json-params
+type EntitlementOwnerType uint8
+
+const (
+	GUILD_ENTITLEMENT_OWNER_TYPE EntitlementOwnerType = iota + 1
+	USER_ENTITLEMENT_OWNER_TYPE
+)
+
+// https://discord.com/developers/docs/monetization/entitlements#entitlement-object-entitlement-structure
+type Entitlement struct {
+	ID            Snowflake       `json:"id"`
+	SKUID         Snowflake       `json:"sku_id"`
+	ApplicationID Snowflake       `json:"application_id"`
+	UserID        Snowflake       `json:"user_id,omitempty"` // Id of the user that is granted access to the entitlement's sku.
+	Type          EntitlementType `json:"type"`
+	Deleted       bool            `json:"deleted"`
+	StartsAt      *time.Time      `json:"starts_at,omitempty"` // Not present when using test entitlements.
+	EndsAt        *time.Time      `json:"ends_at,omitempty"`   // Not present when using test entitlements.
+	GuildID       Snowflake       `json:"guild_id,omitempty"`  // Id of the guild that is granted access to the entitlement's sku.
+	Consumed      bool            `json:"consumed"`            // For consumable items, whether or not the entitlement has been consumed.
+}
+
+// https://discord.com/developers/docs/monetization/entitlements#list-entitlements-query-string-params
+//
+// All fields are optional, leave them empty to skip given filter.
+type EntitlementOptions struct {
+	UserID       Snowflake
+	SKUIDs       []Snowflake
+	Before       Snowflake // Retrieve entitlements before this entitlement id.
+	After        Snowflake // Retrieve entitlements after this entitlement id.
+	Limit        uint8     // Number of entitlements to return, 1-100. (default: 100)
+	GuildID      Snowflake
+	ExcludeEnded bool // Whether ended entitlements should be omitted.
+}
+
+func (options EntitlementOptions) query() url.Values {
+	query := url.Values{}
+	if !options.UserID.IsZero() {
+		query.Set("user_id", options.UserID.String())
+	}
+
+	if len(options.SKUIDs) != 0 {
+		ids := make([]string, len(options.SKUIDs))
+		for i, id := range options.SKUIDs {
+			ids[i] = id.String()
+		}
+		query.Set("sku_ids", strings.Join(ids, ","))
+	}
+
+	if !options.Before.IsZero() {
+		query.Set("before", options.Before.String())
+	}
+
+	if !options.After.IsZero() {
+		query.Set("after", options.After.String())
+	}
+
+	if options.Limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(options.Limit), 10))
+	}
+
+	if !options.GuildID.IsZero() {
+		query.Set("guild_id", options.GuildID.String())
+	}
+
+	if options.ExcludeEnded {
+		query.Set("exclude_ended", "true")
+	}
+
+	return query
+}
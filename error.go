@@ -0,0 +1,30 @@
+package tempest
+
+import "errors"
+
+// Errors returned by local validation, before any request is made to Discord API.
+var (
+	ErrContentTooLong          = errors.New("message content exceeds 2000 characters limit")
+	ErrEmbedTooLong            = errors.New("message embeds exceed Discord's characters limit (4096 per description, 6000 in total)")
+	ErrRowFull                 = errors.New("action row is full (it can hold up to 5 buttons or a single select menu)")
+	ErrTooManyRows             = errors.New("message exceeds limit of 5 action rows")
+	ErrConflictingInviteExpiry = errors.New("invite params cannot have both MaxAge and ExpiresAt set")
+	ErrInvalidEmbedURL         = errors.New("embed image & thumbnail urls need to use https (or attachment://) scheme with valid host and be up to 2048 characters long")
+	ErrTooManyFiles            = errors.New("message exceeds limit of 10 attached files")
+	ErrFileNameMissing         = errors.New("file needs to have a name (with extension)")
+	ErrFileContentTypeMissing  = errors.New("file needs to have a content type")
+	ErrFileTooLarge            = errors.New("file exceeds upload size limit")
+	ErrFilesTooLarge           = errors.New("files exceed upload size limit in total")
+)
+
+// Errors returned by Rest.
+var (
+	ErrResponseTooLarge   = errors.New("discord api response exceeds Rest.MaxResponseSize limit")
+	ErrPriorityRestClosed = errors.New("priority rest is closed (it no longer accepts requests)")
+)
+
+// Errors returned by client's registry.
+var (
+	ErrCommandNotFound = errors.New("command is not registered in client")
+	ErrModalNotFound   = errors.New("modal is not registered in client")
+)
@@ -0,0 +1,44 @@
+package tempest
+
+import "sync"
+
+// Cache of ETag headers (together with response bodies) used by Rest to send conditional GET requests.
+// When Discord replies with 304 Not Modified, Rest returns cached body instead of empty response.
+// Implementations need to be safe for concurrent use.
+type ETagger interface {
+	Get(route string) (etag string, body []byte) // Returns empty etag when route isn't cached.
+	Set(route string, etag string, body []byte)
+}
+
+// In-memory ETagger. It never evicts entries so prefer it for limited set of routes (or implement own ETagger with expiration).
+type MemoryETagger struct {
+	mu      sync.RWMutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+func NewMemoryETagger() *MemoryETagger {
+	return &MemoryETagger{
+		entries: make(map[string]etagEntry),
+	}
+}
+
+func (tagger *MemoryETagger) Get(route string) (string, []byte) {
+	tagger.mu.RLock()
+	entry := tagger.entries[route]
+	tagger.mu.RUnlock()
+	return entry.etag, entry.body
+}
+
+func (tagger *MemoryETagger) Set(route string, etag string, body []byte) {
+	tagger.mu.Lock()
+	if tagger.entries == nil {
+		tagger.entries = make(map[string]etagEntry)
+	}
+	tagger.entries[route] = etagEntry{etag: etag, body: body}
+	tagger.mu.Unlock()
+}
@@ -63,9 +63,8 @@ func main() {
 	client.RegisterComponent([]string{"button-hello"}, command.HelloStatic)
 	client.RegisterModal("my-modal", command.HelloModal)
 
-	err = client.SyncCommands([]tempest.Snowflake{testServerID}, nil, false)
-	if err != nil {
-		logger.Error.Panicln(err)
+	if errs := client.SyncCommands([]tempest.Snowflake{testServerID}, tempest.SyncOptions{}); len(errs) != 0 {
+		logger.Error.Panicln(errs)
 	}
 
 	logger.Info.Printf("Serving application at: %s/discord", addr)
@@ -6,7 +6,7 @@ import (
 
 // Stops any commands executed outside server (obviously not required, just an example).
 func GuildOnly(itx tempest.CommandInteraction) *tempest.ResponseMessageData {
-	if itx.GuildID == 0 {
+	if itx.GuildID.IsZero() {
 		return &tempest.ResponseMessageData{
 			Content: "This command is not allowed to be used in DM channel.",
 		}
@@ -0,0 +1,16 @@
+package tempest
+
+// https://discord.com/developers/docs/topics/gateway#get-gateway-bot-json-response
+type GatewayBotInfo struct {
+	URL               string            `json:"url"`
+	Shards            int               `json:"shards"` // Recommended number of shards to use when connecting.
+	SessionStartLimit SessionStartLimit `json:"session_start_limit"`
+}
+
+// https://discord.com/developers/docs/topics/gateway#session-start-limit-object-session-start-limit-structure
+type SessionStartLimit struct {
+	Total          int `json:"total"`           // Total number of session starts the current user is allowed.
+	Remaining      int `json:"remaining"`       // Remaining number of session starts the current user is allowed.
+	ResetAfter     int `json:"reset_after"`     // Number of milliseconds after which the limit resets.
+	MaxConcurrency int `json:"max_concurrency"` // Number of identify requests allowed per 5 seconds.
+}
@@ -0,0 +1,79 @@
+package tempest
+
+import "time"
+
+// https://discord.com/developers/docs/resources/guild-template#guild-template-object-guild-template-structure
+type GuildTemplate struct {
+	Code          string     `json:"code"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description,omitempty"`
+	UsageCount    uint32     `json:"usage_count"` // Number of times this template has been used.
+	CreatorID     Snowflake  `json:"creator_id"`
+	Creator       *User      `json:"creator,omitempty"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	SourceGuildID Snowflake  `json:"source_guild_id"`
+	IsDirty       bool       `json:"is_dirty,omitempty"` // Whether the template has unsynced changes.
+}
+
+// https://discord.com/developers/docs/resources/guild#guild-widget-settings-object
+type GuildWidget struct {
+	Enabled   bool       `json:"enabled"`
+	ChannelID *Snowflake `json:"channel_id"` // The widget channel id. It's <nil> when widget doesn't point to any channel.
+}
+
+// https://discord.com/developers/docs/resources/guild#guild-widget-object
+type GuildWidgetData struct {
+	ID            Snowflake            `json:"id"`
+	Name          string               `json:"name"`
+	InstantInvite string               `json:"instant_invite,omitempty"` // Instant invite url for the guilds specified widget invite channel.
+	Channels      []GuildWidgetChannel `json:"channels"`                 // Voice and stage channels which are accessible by @everyone.
+	Members       []GuildWidgetMember  `json:"members"`                  // Special widget user objects that includes users presence (limit 100).
+	PresenceCount uint32               `json:"presence_count"`           // Number of online members in this guild.
+}
+
+type GuildWidgetChannel struct {
+	ID       Snowflake `json:"id"`
+	Name     string    `json:"name"`
+	Position uint32    `json:"position"`
+}
+
+// Anonymized user object exposed by guild widget. Its id is not a real user id.
+type GuildWidgetMember struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Discriminator string `json:"discriminator"`
+	AvatarURL     string `json:"avatar_url,omitempty"`
+	Status        string `json:"status"`
+}
+
+// https://discord.com/developers/docs/resources/user#get-current-user-guilds-example-partial-guild
+type PartialGuild struct {
+	ID              Snowflake `json:"id"`
+	Name            string    `json:"name"`
+	IconHash        string    `json:"icon,omitempty"`
+	Owner           bool      `json:"owner"`                        // Whether authorized user is the owner of the guild.
+	PermissionFlags uint64    `json:"permissions,string,omitempty"` // Total permissions of authorized user in the guild (excludes overwrites).
+	Features        []string  `json:"features,omitempty"`
+}
+
+// Returns a direct url to guild's icon. It'll return empty string if guild doesn't have icon.
+func (guild PartialGuild) IconURL() string {
+	if guild.IconHash == "" {
+		return ""
+	}
+	return GuildIconURL(guild.ID, guild.IconHash, 0)
+}
+
+// https://discord.com/developers/docs/resources/guild#ban-object
+type Ban struct {
+	User   User    `json:"user"`
+	Reason *string `json:"reason"` // Reason provided when banning user, <nil> when none was given.
+}
+
+// https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-user-object
+type ScheduledEventUser struct {
+	GuildScheduledEventID Snowflake `json:"guild_scheduled_event_id"`
+	User                  User      `json:"user"`             // User who subscribed to the event.
+	Member                *Member   `json:"member,omitempty"` // Only present when fetched with "with member" option (and user is still guild member).
+}
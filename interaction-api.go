@@ -1,12 +1,44 @@
 package tempest
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/sugawarayuuta/sonnet"
 )
 
+// Returns context of http request that delivered this interaction. It carries values and deadlines set by your http middleware.
+// It'll return background context for interactions that weren't received through client's http handler.
+func (itx CommandInteraction) Context() context.Context {
+	if itx.ctx == nil {
+		return context.Background()
+	}
+	return itx.ctx
+}
+
+// Returns user who invoked command, no matter if it was used in guild (Member.User) or DM (User).
+// It panics when interaction carries neither of them, which never happens for valid Discord payloads.
+func (itx CommandInteraction) Invoker() User {
+	if itx.Member != nil && itx.Member.User != nil {
+		return *itx.Member.User
+	}
+
+	if itx.User != nil {
+		return *itx.User
+	}
+
+	panic("command interaction has neither member nor user attached (invalid interaction payload)")
+}
+
+// Whether interaction was sent from age-restricted (NSFW) channel. It's based on partial channel object sent by Discord together with interaction
+// so it'll return false when that object is missing.
+func (itx CommandInteraction) IsNSFWChannel() bool {
+	return itx.Channel != nil && itx.Channel.NSFW
+}
+
 // Returns value of any type. Check second value to check whether option was provided or not (true if yes).
 func (itx CommandInteraction) GetOptionValue(name string) (any, bool) {
 	options := itx.Data.Options
@@ -23,13 +55,103 @@ func (itx CommandInteraction) GetOptionValue(name string) (any, bool) {
 	return nil, false
 }
 
+// Returns options as map from option name to value. Map is built on first use and cached (shared by all copies of interaction)
+// so treat it as read-only. For subcommands, it contains subcommand's own options.
+func (itx CommandInteraction) OptionsMap() map[string]OptionValue {
+	if itx.options == nil {
+		return buildOptionsMap(itx.Data.Options)
+	}
+
+	itx.options.once.Do(func() {
+		itx.options.values = buildOptionsMap(itx.Data.Options)
+	})
+	return itx.options.values
+}
+
+func buildOptionsMap(options []*CommandInteractionOption) map[string]OptionValue {
+	values := make(map[string]OptionValue, len(options))
+	for _, option := range options {
+		if option != nil {
+			values[option.Name] = OptionValue{Type: option.Type, Value: option.Value}
+		}
+	}
+	return values
+}
+
+// Returns option at given position (in order received from Discord). Second value is false when index is out of range.
+// For subcommands, it indexes subcommand's own options.
+func (itx CommandInteraction) OptionByIndex(i int) (CommandInteractionOption, bool) {
+	if i < 0 || i >= len(itx.Data.Options) || itx.Data.Options[i] == nil {
+		return CommandInteractionOption{}, false
+	}
+	return *itx.Data.Options[i], true
+}
+
+// Returns number of options provided by user.
+func (itx CommandInteraction) OptionCount() int {
+	return len(itx.Data.Options)
+}
+
+// Returns all value options (everything except subcommands & subcommand groups) from interaction's option tree as single, flat list.
+// Options are listed in order received from Discord (depth-first), which makes it easy to read options of deeply nested commands.
+func (itx CommandInteraction) FlatOptions() []CommandInteractionOption {
+	return appendFlatOptions(make([]CommandInteractionOption, 0, len(itx.Data.Options)), itx.Data.Options)
+}
+
+func appendFlatOptions(list []CommandInteractionOption, options []*CommandInteractionOption) []CommandInteractionOption {
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		if option.Type == SUB_OPTION_TYPE || option.Type == SUB_COMMAND_GROUP_OPTION_TYPE {
+			list = appendFlatOptions(list, option.Options)
+			continue
+		}
+
+		list = append(list, *option)
+	}
+	return list
+}
+
+// Returns string option parsed as absolute http(s) url. Second value is false when option wasn't provided, error is set when provided value isn't valid url.
+func (itx CommandInteraction) GetURL(name string) (*url.URL, bool, error) {
+	value, provided := itx.GetOptionValue(name)
+	if !provided {
+		return nil, false, nil
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return nil, true, errors.New("\"" + name + "\" option is not a string option")
+	}
+
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, true, errors.New("\"" + raw + "\" is not valid url")
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, true, errors.New("\"" + raw + "\" is not valid http or https url")
+	}
+
+	return parsed, true, nil
+}
+
 // Returns pointer to user if present in interaction.data.resolved. It'll return <nil> if there's no resolved user.
 func (itx CommandInteraction) ResolveUser(id Snowflake) *User {
+	if itx.Data.Resolved == nil {
+		return nil
+	}
 	return itx.Data.Resolved.Users[id]
 }
 
 // Returns pointer to member if present in interaction.data.resolved and binds member.user. It'll return <nil> if there's no resolved member.
 func (itx CommandInteraction) ResolveMember(id Snowflake) *Member {
+	if itx.Data.Resolved == nil {
+		return nil
+	}
+
 	member, available := itx.Data.Resolved.Members[id]
 	if available {
 		member.User = itx.Data.Resolved.Users[id]
@@ -40,40 +162,110 @@ func (itx CommandInteraction) ResolveMember(id Snowflake) *Member {
 
 // Returns pointer to guild role if present in interaction.data.resolved. It'll return <nil> if there's no resolved role.
 func (itx CommandInteraction) ResolveRole(id Snowflake) *Role {
+	if itx.Data.Resolved == nil {
+		return nil
+	}
 	return itx.Data.Resolved.Roles[id]
 }
 
+// Returns copy of user from interaction.data.resolved. Second value is false if there's no such resolved user.
+func (itx CommandInteraction) ResolvedUser(id Snowflake) (User, bool) {
+	user := itx.ResolveUser(id)
+	if user == nil {
+		return User{}, false
+	}
+	return *user, true
+}
+
+// Returns copy of member (with bound member.user and member.guild_id) from interaction.data.resolved. Second value is false if there's no such resolved member.
+func (itx CommandInteraction) ResolvedMember(id Snowflake) (Member, bool) {
+	member := itx.ResolveMember(id)
+	if member == nil {
+		return Member{}, false
+	}
+
+	res := *member
+	res.GuildID = itx.GuildID
+	return res, true
+}
+
+// Returns copy of role from interaction.data.resolved. Second value is false if there's no such resolved role.
+func (itx CommandInteraction) ResolvedRole(id Snowflake) (Role, bool) {
+	role := itx.ResolveRole(id)
+	if role == nil {
+		return Role{}, false
+	}
+	return *role, true
+}
+
+// Returns copy of channel from interaction.data.resolved. Second value is false if there's no such resolved channel.
+func (itx CommandInteraction) ResolvedChannel(id Snowflake) (PartialChannel, bool) {
+	if itx.Data.Resolved == nil {
+		return PartialChannel{}, false
+	}
+
+	channel, available := itx.Data.Resolved.Channels[id]
+	if !available || channel == nil {
+		return PartialChannel{}, false
+	}
+	return *channel, true
+}
+
+// Returns copy of attachment from interaction.data.resolved. Second value is false if there's no such resolved attachment.
+func (itx CommandInteraction) ResolvedAttachment(id Snowflake) (Attachment, bool) {
+	if itx.Data.Resolved == nil {
+		return Attachment{}, false
+	}
+
+	attachment, available := itx.Data.Resolved.Attachments[id]
+	if !available || attachment == nil {
+		return Attachment{}, false
+	}
+	return *attachment, true
+}
+
 // Use to let user/member know that bot is processing command.
 // Make ephemeral = true to make notification visible only to target.
+// It does nothing if client already deferred this interaction automatically (see ClientOptions.AutoDefer).
 func (itx *CommandInteraction) Defer(ephemeral bool) error {
+	if itx.acknowledge() {
+		return nil
+	}
+
 	var flags uint64 = 0
 
 	if ephemeral {
-		flags = 64
+		flags = EPHEMERAL_MESSAGE_FLAG
 	}
 
-	_, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
+	_, _, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
 		Type: DEFERRED_CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
 		Data: &ResponseMessageData{
 			Flags: flags,
 		},
 	})
 
-	return err
+	return itx.settle(err)
 }
 
 // Acknowledges the interaction with a message. Set ephemeral = true to make message visible only to target.
+// When client already deferred this interaction automatically (see ClientOptions.AutoDefer), it'll edit deferred reply instead
+// (ephemeral state cannot be changed at that point).
 func (itx *CommandInteraction) SendReply(content ResponseMessageData, ephemeral bool) error {
-	if ephemeral && content.Flags == 0 {
-		content.Flags = 64
+	if itx.acknowledge() {
+		return itx.settle(itx.EditReply(content, false))
+	}
+
+	if ephemeral {
+		content.Flags |= EPHEMERAL_MESSAGE_FLAG
 	}
 
-	_, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
+	_, _, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
 		Type: CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
 		Data: &content,
 	})
 
-	return err
+	return itx.settle(err)
 }
 
 // Use that for simple text messages that won't be modified.
@@ -83,35 +275,171 @@ func (itx *CommandInteraction) SendLinearReply(content string, ephemeral bool) e
 	}, ephemeral)
 }
 
+// Replies with simple text message visible only to target.
+func (itx *CommandInteraction) RespondEphemeral(message string) error {
+	return itx.SendLinearReply(message, true)
+}
+
+// Replies with single embed (without any content).
+func (itx *CommandInteraction) RespondEmbed(embed Embed) error {
+	return itx.SendReply(ResponseMessageData{
+		Embeds: []*Embed{&embed},
+	}, false)
+}
+
+// Replies with single embed (without any content) visible only to target.
+func (itx *CommandInteraction) RespondEphemeralEmbed(embed Embed) error {
+	return itx.SendReply(ResponseMessageData{
+		Embeds: []*Embed{&embed},
+	}, true)
+}
+
 func (itx *CommandInteraction) SendModal(modal ResponseModalData) error {
-	_, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseModal{
+	if itx.acknowledge() {
+		return errors.New("cannot send modal to interaction that was already deferred")
+	}
+
+	_, _, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseModal{
 		Type: MODAL_RESPONSE_TYPE,
 		Data: &modal,
 	})
 
-	return err
+	return itx.settle(err)
+}
+
+// Returns channel that closes once handler can no longer respond - either handler's own response succeeded or INTERACTION_RESPONSE_DEADLINE
+// passed since interaction was received. Handlers doing slow work can select on it to bail out early. When client responds on handler's behalf
+// (ClientOptions.AutoDefer or ClientOptions.TimeoutResponse), handler's reply edits that response instead, so deadline is extended to
+// INTERACTION_TOKEN_LIFETIME. For interactions that weren't received through client's http handler it returns <nil> (never closing) channel.
+func (itx CommandInteraction) Done() <-chan struct{} {
+	if itx.state == nil {
+		return nil
+	}
+
+	// No lock here, client may hold it for whole request when responding on handler's behalf.
+	return itx.state.done
+}
+
+// Marks interaction as acknowledged, so client won't respond on handler's behalf while its request is in-flight.
+// Returns true when client has already deferred (or answered) it. Call CommandInteraction.settle with request's result afterwards.
+func (itx CommandInteraction) acknowledge() bool {
+	if itx.state == nil {
+		return false
+	}
+
+	itx.state.mu.Lock()
+	defer itx.state.mu.Unlock()
+
+	if itx.state.autoDeferred {
+		return true
+	}
+
+	itx.state.responded = true
+	return false
+}
+
+// Finishes interaction after handler's successful response. Failed response is rolled back, so client can still defer (or answer) it.
+func (itx CommandInteraction) settle(err error) error {
+	if itx.state == nil {
+		return err
+	}
+
+	itx.state.mu.Lock()
+	defer itx.state.mu.Unlock()
+
+	if err != nil {
+		if !itx.state.autoDeferred {
+			itx.state.responded = false
+		}
+		return err
+	}
+
+	if itx.state.timeout != nil {
+		itx.state.timeout.Stop()
+	}
+
+	itx.state.finishLocked()
+	return nil
+}
+
+// Defers interaction unless handler has already responded to it. Lock is held during request so handler's reply can't overtake deferred response.
+func (itx CommandInteraction) autoDefer() {
+	itx.state.mu.Lock()
+	defer itx.state.mu.Unlock()
+
+	if itx.state.responded {
+		return
+	}
+
+	itx.state.responded = true
+	_, _, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
+		Type: DEFERRED_CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
+	})
+
+	if err != nil {
+		itx.state.responded = false // Rolled back, so ClientOptions.TimeoutResponse can still be sent.
+		if itx.Client.logger != nil {
+			itx.Client.logger.Printf("ERROR failed to automatically defer interaction id=%s: %s", itx.ID, err)
+		}
+		return
+	}
+
+	itx.state.autoDeferred = true
+	itx.state.extendDeadlineLocked()
+}
+
+// Sends fallback response when handler didn't respond within ClientOptions.ResponseTimeout.
+// Later replies from handler will edit fallback message (same as with automatic deferral).
+func (itx CommandInteraction) respondOnTimeout(content ResponseMessageData) {
+	itx.state.mu.Lock()
+	defer itx.state.mu.Unlock()
+
+	if itx.state.responded {
+		return
+	}
+
+	itx.state.responded = true
+	if itx.Client.logger != nil {
+		itx.Client.logger.Printf("WARN command handler didn't respond in time, sending timeout response id=%s command=%s", itx.ID, itx.Data.Name)
+	}
+
+	_, _, err := itx.Client.Rest.Request(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", ResponseMessage{
+		Type: CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
+		Data: &content,
+	})
+
+	if err != nil {
+		itx.state.responded = false
+		if itx.Client.logger != nil {
+			itx.Client.logger.Printf("ERROR failed to send timeout response to interaction id=%s: %s", itx.ID, err)
+		}
+		return
+	}
+
+	itx.state.autoDeferred = true
+	itx.state.extendDeadlineLocked()
 }
 
 func (itx CommandInteraction) EditReply(content ResponseMessageData, ephemeral bool) error {
-	if ephemeral && content.Flags == 0 {
-		content.Flags = 64
+	if ephemeral {
+		content.Flags |= EPHEMERAL_MESSAGE_FLAG
 	}
 
-	_, err := itx.Client.Rest.Request(http.MethodPatch, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/@original", content)
+	_, _, err := itx.Client.Rest.Request(http.MethodPatch, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/@original", content)
 	return err
 }
 
 func (itx CommandInteraction) DeleteReply() error {
-	_, err := itx.Client.Rest.Request(http.MethodDelete, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/@original", nil)
+	_, _, err := itx.Client.Rest.Request(http.MethodDelete, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/@original", nil)
 	return err
 }
 
 func (itx CommandInteraction) SendFollowUp(content ResponseMessageData, ephemeral bool) (Message, error) {
-	if ephemeral && content.Flags == 0 {
-		content.Flags = 64
+	if ephemeral {
+		content.Flags |= EPHEMERAL_MESSAGE_FLAG
 	}
 
-	raw, err := itx.Client.Rest.Request(http.MethodPost, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token, content)
+	raw, _, err := itx.Client.Rest.Request(http.MethodPost, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token, content)
 	if err != nil {
 		return Message{}, err
 	}
@@ -126,15 +454,24 @@ func (itx CommandInteraction) SendFollowUp(content ResponseMessageData, ephemera
 }
 
 func (itx CommandInteraction) EditFollowUp(messageID Snowflake, content ResponseMessage) error {
-	_, err := itx.Client.Rest.Request(http.MethodPatch, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/"+messageID.String(), content)
+	_, _, err := itx.Client.Rest.Request(http.MethodPatch, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/"+messageID.String(), content)
 	return err
 }
 
 func (itx CommandInteraction) DeleteFollowUp(messageID Snowflake, content ResponseMessage) error {
-	_, err := itx.Client.Rest.Request(http.MethodDelete, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/"+messageID.String(), content)
+	_, _, err := itx.Client.Rest.Request(http.MethodDelete, "/webhooks/"+itx.ApplicationID.String()+"/"+itx.Token+"/messages/"+messageID.String(), content)
 	return err
 }
 
+// Returns context of http request that delivered this interaction.
+// It'll return background context for interactions that weren't received through client's http handler.
+func (itx AutoCompleteInteraction) Context() context.Context {
+	if itx.ctx == nil {
+		return context.Background()
+	}
+	return itx.ctx
+}
+
 // Returns option name and its value of triggered option. Option name is always of string type but you'll need to check type of value.
 func (itx AutoCompleteInteraction) GetFocusedValue() (string, any) {
 	options := itx.Data.Options
@@ -148,38 +485,31 @@ func (itx AutoCompleteInteraction) GetFocusedValue() (string, any) {
 	panic("auto complete interaction had no option with \"focused\" field. This error should never happen with correctly defined slash command")
 }
 
+// Returns context of http request that delivered this interaction.
+// It'll return background context for interactions that weren't received through client's http handler.
+func (itx ComponentInteraction) Context() context.Context {
+	if itx.ctx == nil {
+		return context.Background()
+	}
+	return itx.ctx
+}
+
 // Sends to discord info that this component was handled successfully without sending anything more.
 func (itx ComponentInteraction) Acknowledge() error {
-	body, err := sonnet.Marshal(ResponseMessage{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseMessage{
 		Type: DEFERRED_UPDATE_MESSAGE_RESPONSE_TYPE,
 	})
-
-	if err != nil {
-		return err
-	}
-
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
 }
 
 func (itx ComponentInteraction) AcknowledgeWithMessage(content ResponseMessageData, ephemeral bool) error {
-	if ephemeral && content.Flags == 0 {
-		content.Flags = 64
+	if ephemeral {
+		content.Flags |= EPHEMERAL_MESSAGE_FLAG
 	}
 
-	body, err := sonnet.Marshal(ResponseMessage{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseMessage{
 		Type: CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
 		Data: &content,
 	})
-
-	if err != nil {
-		return err
-	}
-
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
 }
 
 func (itx ComponentInteraction) AcknowledgeWithLinearMessage(content string, ephemeral bool) error {
@@ -189,18 +519,38 @@ func (itx ComponentInteraction) AcknowledgeWithLinearMessage(content string, eph
 }
 
 func (itx ComponentInteraction) AcknowledgeWithModal(modal ResponseModalData) error {
-	body, err := sonnet.Marshal(ResponseModal{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseModal{
 		Type: MODAL_RESPONSE_TYPE,
 		Data: &modal,
 	})
+}
 
-	if err != nil {
-		return err
+// Responds to component (like button click) with modal. It's the same as AcknowledgeWithModal, named after CommandInteraction.SendModal.
+// Use Client.AwaitModal or Client.RegisterModal to handle its submission, for example to build confirmation flows.
+func (itx ComponentInteraction) SendModal(modal ResponseModalData) error {
+	return itx.AcknowledgeWithModal(modal)
+}
+
+// Returns first selected value. Second value is false when nothing was selected. Handy for select menus limited to single value.
+func (itx SelectMenuInteraction) SingleValue() (string, bool) {
+	if len(itx.Data.Values) == 0 {
+		return "", false
 	}
+	return itx.Data.Values[0], true
+}
 
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
+// Returns all selected values (same as Data.Values). For user, role, mentionable & channel selects those are snowflake ids.
+func (itx SelectMenuInteraction) AllValues() []string {
+	return itx.Data.Values
+}
+
+// Returns context of http request that delivered this interaction.
+// It'll return background context for interactions that weren't received through client's http handler.
+func (itx ModalInteraction) Context() context.Context {
+	if itx.ctx == nil {
+		return context.Background()
+	}
+	return itx.ctx
 }
 
 // Returns value of any type. It will return empty string on no value or empty value.
@@ -225,36 +575,20 @@ func (itx ModalInteraction) GetInputValue(customID string) string {
 
 // Sends to discord info that this component was handled successfully without sending anything more.
 func (itx ModalInteraction) Acknowledge() error {
-	body, err := sonnet.Marshal(ResponseMessage{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseMessage{
 		Type: DEFERRED_UPDATE_MESSAGE_RESPONSE_TYPE,
 	})
-
-	if err != nil {
-		return err
-	}
-
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
 }
 
 func (itx ModalInteraction) AcknowledgeWithMessage(content ResponseMessageData, ephemeral bool) error {
-	if ephemeral && content.Flags == 0 {
-		content.Flags = 64
+	if ephemeral {
+		content.Flags |= EPHEMERAL_MESSAGE_FLAG
 	}
 
-	body, err := sonnet.Marshal(ResponseMessage{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseMessage{
 		Type: CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE,
 		Data: &content,
 	})
-
-	if err != nil {
-		return err
-	}
-
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
 }
 
 func (itx ModalInteraction) AcknowledgeWithLinearMessage(content string, ephemeral bool) error {
@@ -264,16 +598,30 @@ func (itx ModalInteraction) AcknowledgeWithLinearMessage(content string, ephemer
 }
 
 func (itx ModalInteraction) AcknowledgeWithModal(modal ResponseModalData) error {
-	body, err := sonnet.Marshal(ResponseModal{
+	return writeInteractionResponse(itx.w, itx.Client, itx.ID, itx.Token, ResponseModal{
 		Type: MODAL_RESPONSE_TYPE,
 		Data: &modal,
 	})
+}
+
+// Writes initial response to component or modal interaction. Interactions that weren't received through client's http handler
+// (like ones parsed with UnmarshalComponentInteraction) respond through interaction callback endpoint instead.
+func writeInteractionResponse(w http.ResponseWriter, client *Client, interactionID Snowflake, token string, response any) error {
+	if w == nil {
+		if client == nil {
+			return errors.New("interaction has no client set (assign it before responding to interaction parsed outside of client)")
+		}
+
+		_, _, err := client.Rest.Request(http.MethodPost, "/interactions/"+interactionID.String()+"/"+token+"/callback", response)
+		return err
+	}
 
+	body, err := sonnet.Marshal(response)
 	if err != nil {
 		return err
 	}
 
-	itx.w.Header().Add("Content-Type", "application/json")
-	itx.w.Write(body)
-	return err
+	w.Header().Add("Content-Type", "application/json")
+	w.Write(body)
+	return nil
 }
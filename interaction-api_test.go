@@ -0,0 +1,212 @@
+package tempest
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetURL(t *testing.T) {
+	itx := CommandInteraction{Data: CommandInteractionData{Options: []*CommandInteractionOption{
+		{Name: "image", Type: STRING_OPTION_TYPE, Value: "https://cdn.discordapp.com/embed/avatars/0.png"},
+		{Name: "script", Type: STRING_OPTION_TYPE, Value: "javascript:alert(1)"},
+		{Name: "amount", Type: INTEGER_OPTION_TYPE, Value: float64(5)},
+	}}}
+
+	parsed, provided, err := itx.GetURL("image")
+	if err != nil || !provided || parsed.Host != "cdn.discordapp.com" {
+		t.Errorf("expected valid url, got: %v, %t, %v", parsed, provided, err)
+	}
+
+	if _, provided, err := itx.GetURL("script"); !provided || err == nil {
+		t.Error("expected error for non http url")
+	}
+
+	if _, provided, err := itx.GetURL("amount"); !provided || err == nil {
+		t.Error("expected error for non string option")
+	}
+
+	if _, provided, err := itx.GetURL("missing"); provided || err != nil {
+		t.Error("expected missing option to be reported as not provided")
+	}
+}
+
+func TestOptionByIndex(t *testing.T) {
+	itx := CommandInteraction{Data: CommandInteractionData{Options: []*CommandInteractionOption{
+		{Name: "first", Type: STRING_OPTION_TYPE, Value: "a"},
+		{Name: "second", Type: INTEGER_OPTION_TYPE, Value: float64(2)},
+	}}}
+
+	if itx.OptionCount() != 2 {
+		t.Errorf("expected 2 options, got: %d", itx.OptionCount())
+	}
+
+	if option, available := itx.OptionByIndex(1); !available || option.Name != "second" {
+		t.Errorf("expected second option, got: %+v", option)
+	}
+
+	if _, available := itx.OptionByIndex(2); available {
+		t.Error("expected out of range index to be reported as unavailable")
+	}
+
+	if _, available := itx.OptionByIndex(-1); available {
+		t.Error("expected negative index to be reported as unavailable")
+	}
+}
+
+func TestFlatOptions(t *testing.T) {
+	itx := CommandInteraction{Data: CommandInteractionData{Options: []*CommandInteractionOption{
+		{Name: "manage", Type: SUB_COMMAND_GROUP_OPTION_TYPE, Options: []*CommandInteractionOption{
+			{Name: "edit", Type: SUB_OPTION_TYPE, Options: []*CommandInteractionOption{
+				{Name: "name", Type: STRING_OPTION_TYPE, Value: "rules"},
+				{Name: "pinned", Type: BOOLEAN_OPTION_TYPE, Value: true},
+			}},
+		}},
+	}}}
+
+	options := itx.FlatOptions()
+	if len(options) != 2 || options[0].Name != "name" || options[1].Name != "pinned" {
+		t.Errorf("unexpected flat options: %+v", options)
+	}
+
+	if len((CommandInteraction{}).FlatOptions()) != 0 {
+		t.Error("expected no options for interaction without options")
+	}
+}
+
+func TestOptionsMap(t *testing.T) {
+	itx := CommandInteraction{
+		Data: CommandInteractionData{Options: []*CommandInteractionOption{
+			{Name: "text", Type: STRING_OPTION_TYPE, Value: "hello"},
+			{Name: "amount", Type: INTEGER_OPTION_TYPE, Value: float64(5)},
+			{Name: "silent", Type: BOOLEAN_OPTION_TYPE, Value: true},
+			{Name: "user", Type: USER_OPTION_TYPE, Value: "123"},
+		}},
+		options: &optionsCache{},
+	}
+
+	options := itx.OptionsMap()
+	if value, ok := options["text"].AsString(); !ok || value != "hello" {
+		t.Errorf("unexpected string option: %q", value)
+	}
+
+	if value, ok := options["amount"].AsInt(); !ok || value != 5 {
+		t.Errorf("unexpected integer option: %d", value)
+	}
+
+	if value, ok := options["silent"].AsBool(); !ok || !value {
+		t.Error("unexpected boolean option")
+	}
+
+	if value, ok := options["user"].AsSnowflake(); !ok || value != 123 {
+		t.Errorf("unexpected user option: %d", value)
+	}
+
+	if _, ok := options["text"].AsInt(); ok {
+		t.Error("expected type mismatch to be reported")
+	}
+
+	itx.Data.Options = nil
+	if len(itx.OptionsMap()) != 4 {
+		t.Error("expected options map to be cached")
+	}
+}
+
+func TestComponentSendModal(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	itx := ComponentInteraction{w: recorder}
+
+	if err := itx.SendModal(ResponseModalData{CustomID: "confirm", Title: "Are you sure?"}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := recorder.Body.String()
+	if !strings.Contains(body, `"type":9`) || !strings.Contains(body, `"custom_id":"confirm"`) {
+		t.Errorf("expected modal response, got: %s", body)
+	}
+}
+
+func TestSelectMenuValues(t *testing.T) {
+	itx := SelectMenuInteraction{ComponentInteraction{Data: ComponentInteractionData{Values: []string{"red", "blue"}}}}
+	if value, selected := itx.SingleValue(); !selected || value != "red" {
+		t.Errorf("expected first selected value, got: %q", value)
+	}
+
+	if values := itx.AllValues(); len(values) != 2 {
+		t.Errorf("expected all selected values, got: %v", values)
+	}
+
+	if _, selected := (SelectMenuInteraction{}).SingleValue(); selected {
+		t.Error("expected no value for empty selection")
+	}
+}
+
+func TestInvoker(t *testing.T) {
+	guild := CommandInteraction{GuildID: 1, Member: &Member{User: &User{ID: 2}}}
+	if guild.Invoker().ID != 2 {
+		t.Error("expected invoker to be read from member in guild")
+	}
+
+	dm := CommandInteraction{User: &User{ID: 3}}
+	if dm.Invoker().ID != 3 {
+		t.Error("expected invoker to be read from user in DM")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for interaction without member & user")
+		}
+	}()
+	(CommandInteraction{}).Invoker()
+}
+
+func TestInteractionDone(t *testing.T) {
+	if (CommandInteraction{}).Done() != nil {
+		t.Error("expected nil channel for interaction received outside of client")
+	}
+
+	itx := CommandInteraction{state: &interactionState{done: make(chan struct{})}}
+	done := itx.Done()
+	select {
+	case <-done:
+		t.Fatal("expected channel to stay open before response")
+	default:
+	}
+
+	itx.acknowledge()
+	itx.settle(errors.New("unknown interaction"))
+	select {
+	case <-done:
+		t.Fatal("expected channel to stay open after failed response")
+	default:
+	}
+
+	if itx.state.responded {
+		t.Error("expected failed response to be rolled back")
+	}
+
+	itx.acknowledge()
+	itx.settle(nil)
+	select {
+	case <-done:
+	default:
+		t.Error("expected channel to close after response")
+	}
+
+	late := CommandInteraction{state: &interactionState{done: make(chan struct{})}}
+	late.state.finish()
+	select {
+	case <-late.Done():
+	default:
+		t.Error("expected channel requested after deadline to be already closed")
+	}
+
+	// Client holds lock during its own response request, Done mustn't wait for it.
+	busy := CommandInteraction{state: &interactionState{done: make(chan struct{})}}
+	busy.state.mu.Lock()
+	defer busy.state.mu.Unlock()
+	if busy.Done() == nil {
+		t.Error("expected channel while client holds interaction's lock")
+	}
+}
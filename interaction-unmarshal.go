@@ -0,0 +1,53 @@
+package tempest
+
+import (
+	"errors"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+// Parses raw command (or auto complete) interaction payload, for example one received through gateway connection.
+// Returned interaction isn't bound to any client - set its Client field before using response methods.
+func UnmarshalCommandInteraction(data []byte) (CommandInteraction, error) {
+	var itx CommandInteraction
+	if err := sonnet.Unmarshal(data, &itx); err != nil {
+		return CommandInteraction{}, errors.New("failed to parse command interaction: " + err.Error())
+	}
+
+	if itx.Type != APPLICATION_COMMAND_INTERACTION_TYPE && itx.Type != APPLICATION_COMMAND_AUTO_COMPLETE_INTERACTION_TYPE {
+		return CommandInteraction{}, errors.New("expected command interaction but received " + itx.Type.String() + " interaction")
+	}
+
+	itx.options = &optionsCache{}
+	return itx, nil
+}
+
+// Parses raw component interaction payload, for example one received through gateway connection.
+// Returned interaction isn't bound to any client - set its Client field before using response methods (they'll respond through Rest).
+func UnmarshalComponentInteraction(data []byte) (ComponentInteraction, error) {
+	var itx ComponentInteraction
+	if err := sonnet.Unmarshal(data, &itx); err != nil {
+		return ComponentInteraction{}, errors.New("failed to parse component interaction: " + err.Error())
+	}
+
+	if itx.Type != MESSAGE_COMPONENT_INTERACTION_TYPE {
+		return ComponentInteraction{}, errors.New("expected component interaction but received " + itx.Type.String() + " interaction")
+	}
+
+	return itx, nil
+}
+
+// Parses raw modal submit interaction payload, for example one received through gateway connection.
+// Returned interaction isn't bound to any client - set its Client field before using response methods (they'll respond through Rest).
+func UnmarshalModalInteraction(data []byte) (ModalInteraction, error) {
+	var itx ModalInteraction
+	if err := sonnet.Unmarshal(data, &itx); err != nil {
+		return ModalInteraction{}, errors.New("failed to parse modal interaction: " + err.Error())
+	}
+
+	if itx.Type != MODAL_SUBMIT_INTERACTION_TYPE {
+		return ModalInteraction{}, errors.New("expected modal interaction but received " + itx.Type.String() + " interaction")
+	}
+
+	return itx, nil
+}
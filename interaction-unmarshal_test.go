@@ -0,0 +1,55 @@
+package tempest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalInteraction(t *testing.T) {
+	itx, err := UnmarshalCommandInteraction([]byte(`{"id":"1","type":2,"token":"abc","data":{"name":"ping","type":1,"options":[{"name":"count","type":4,"value":3}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count, _ := itx.OptionsMap()["count"].AsInt(); itx.Data.Name != "ping" || count != 3 {
+		t.Errorf("unexpected command interaction: %+v", itx)
+	}
+
+	if _, err := UnmarshalCommandInteraction([]byte(`{"id":"1","type":3}`)); err == nil {
+		t.Error("expected error for interaction of other type")
+	}
+
+	if _, err := UnmarshalModalInteraction([]byte(`{"id":`)); err == nil {
+		t.Error("expected error for malformed payload")
+	}
+}
+
+func TestComponentInteractionRespondsThroughRest(t *testing.T) {
+	var route, body string
+	rest := newTestRest(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		route, body = r.URL.Path, string(raw)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	itx, err := UnmarshalComponentInteraction([]byte(`{"id":"7","type":3,"token":"abc","data":{"custom_id":"next","component_type":2}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := itx.Acknowledge(); err == nil {
+		t.Error("expected error when responding without client")
+	}
+
+	itx.Client = NewClient(ClientOptions{Rest: rest})
+
+	if err := itx.AcknowledgeWithLinearMessage("done", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if route != "/interactions/7/abc/callback" || !strings.Contains(body, `"content":"done"`) {
+		t.Errorf("unexpected callback request: %s %s", route, body)
+	}
+}
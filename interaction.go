@@ -1,6 +1,11 @@
 package tempest
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object
 type AutoCompleteInteraction CommandInteraction
@@ -16,11 +21,36 @@ const (
 	MODAL_SUBMIT_INTERACTION_TYPE
 )
 
+func (it InteractionType) String() string {
+	switch it {
+	case PING_INTERACTION_TYPE:
+		return "PING"
+	case APPLICATION_COMMAND_INTERACTION_TYPE:
+		return "APPLICATION_COMMAND"
+	case MESSAGE_COMPONENT_INTERACTION_TYPE:
+		return "MESSAGE_COMPONENT"
+	case APPLICATION_COMMAND_AUTO_COMPLETE_INTERACTION_TYPE:
+		return "APPLICATION_COMMAND_AUTOCOMPLETE"
+	case MODAL_SUBMIT_INTERACTION_TYPE:
+		return "MODAL_SUBMIT"
+	}
+	return "UNKNOWN"
+}
+
 // Used only for partial JSON parsing.
 type InteractionTypeExtractor struct {
 	Type InteractionType `json:"type"`
 }
 
+// Used only for partial JSON parsing of debug logs.
+type interactionDebugExtractor struct {
+	Type      InteractionType `json:"type"`
+	GuildID   Snowflake       `json:"guild_id,omitempty"`
+	ChannelID Snowflake       `json:"channel_id,omitempty"`
+	Member    *Member         `json:"member,omitempty"`
+	User      *User           `json:"user,omitempty"`
+}
+
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object
 type CommandInteraction struct {
 	ID              Snowflake              `json:"id"`
@@ -29,6 +59,7 @@ type CommandInteraction struct {
 	Data            CommandInteractionData `json:"data"`
 	GuildID         Snowflake              `json:"guild_id,omitempty"`
 	ChannelID       Snowflake              `json:"channel_id,omitempty"`
+	Channel         *Channel               `json:"channel,omitempty"` // Partial channel that the interaction was sent from.
 	Member          *Member                `json:"member,omitempty"`
 	User            *User                  `json:"user,omitempty"`
 	Token           string                 `json:"token"`                  // Temporary token used for responding to the interaction. It's not the same as bot/app token.
@@ -37,7 +68,66 @@ type CommandInteraction struct {
 	Locale          string                 `json:"locale,omitempty"`       // Selected language of the invoking user.
 	GuildLocale     string                 `json:"guild_locale,omitempty"` // Guild's preferred locale, available if invoked in a guild.
 
-	Client *Client `json:"-"`
+	Client  *Client           `json:"-"`
+	ctx     context.Context   `json:"-"`
+	state   *interactionState `json:"-"` // Shared between all copies of interaction, used by automatic deferral & CommandInteraction.Done.
+	options *optionsCache     `json:"-"` // Shared between all copies of interaction, used by CommandInteraction.OptionsMap.
+}
+
+// Lazily built lookup table of interaction's options.
+type optionsCache struct {
+	once   sync.Once
+	values map[string]OptionValue
+}
+
+// Tracks whether command interaction got acknowledged. Set for all command interactions received through client's http handler.
+type interactionState struct {
+	mu           sync.Mutex
+	responded    bool
+	autoDeferred bool          // Whether client has deferred (or answered with timeout response) interaction on handler's behalf.
+	deferTimer   *time.Timer   // Timer that defers interaction (see ClientOptions.AutoDefer), stopped once handler returns.
+	timeout      *time.Timer   // Timer that sends ClientOptions.TimeoutResponse, stopped once handler responds or returns.
+	received     time.Time     // When client received interaction, used to compute token expiration.
+	deadline     *time.Timer   // Timer that finishes state, extended to token's lifetime once client answers on handler's behalf.
+	finished     bool          // Whether window for handler's response has closed (handler responded or deadline passed).
+	done         chan struct{} // Created together with state (never reassigned, so reading it needs no lock), closed once finished.
+}
+
+// Marks window for initial response as closed. Requires mu to be held.
+func (state *interactionState) finishLocked() {
+	if state.finished {
+		return
+	}
+
+	state.finished = true
+	if state.done != nil {
+		close(state.done)
+	}
+}
+
+func (state *interactionState) finish() {
+	state.mu.Lock()
+	state.finishLocked()
+	state.mu.Unlock()
+}
+
+// Stops timers that respond on handler's behalf. Timers are only assigned before handler runs so it doesn't need mu
+// (which may be held for whole request by CommandInteraction.autoDefer).
+func (state *interactionState) stopTimers() {
+	if state.deferTimer != nil {
+		state.deferTimer.Stop()
+	}
+
+	if state.timeout != nil {
+		state.timeout.Stop()
+	}
+}
+
+// Moves deadline to the end of interaction token's lifetime, as handler can still edit reply sent on its behalf. Requires mu to be held.
+func (state *interactionState) extendDeadlineLocked() {
+	if state.deadline != nil {
+		state.deadline.Reset(time.Until(state.received.Add(INTERACTION_TOKEN_LIFETIME)))
+	}
 }
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object
@@ -48,6 +138,7 @@ type ComponentInteraction struct {
 	Data            ComponentInteractionData `json:"data"`
 	GuildID         Snowflake                `json:"guild_id,omitempty"`
 	ChannelID       Snowflake                `json:"channel_id,omitempty"`
+	Channel         *Channel                 `json:"channel,omitempty"` // Partial channel that the interaction was sent from.
 	Member          *Member                  `json:"member,omitempty"`
 	User            *User                    `json:"user,omitempty"`
 	Token           string                   `json:"token"`   // Temporary token used for responding to the interaction. It's not the same as bot/app token.
@@ -59,6 +150,7 @@ type ComponentInteraction struct {
 
 	Client *Client             `json:"-"`
 	w      http.ResponseWriter `json:"-"`
+	ctx    context.Context     `json:"-"`
 }
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object
@@ -69,6 +161,7 @@ type ModalInteraction struct {
 	Data            ModalInteractionData `json:"data"`
 	GuildID         Snowflake            `json:"guild_id,omitempty"`
 	ChannelID       Snowflake            `json:"channel_id,omitempty"`
+	Channel         *Channel             `json:"channel,omitempty"` // Partial channel that the interaction was sent from.
 	Member          *Member              `json:"member,omitempty"`
 	User            *User                `json:"user,omitempty"`
 	Token           string               `json:"token"`                  // Temporary token used for responding to the interaction. It's not the same as bot/app token.
@@ -79,6 +172,7 @@ type ModalInteraction struct {
 
 	Client *Client             `json:"-"`
 	w      http.ResponseWriter `json:"-"`
+	ctx    context.Context     `json:"-"`
 }
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-application-command-data-structure
@@ -101,12 +195,52 @@ type CommandInteractionOption struct {
 	Focused bool                        `json:"focused,omitempty"`
 }
 
+// Value of single command option, returned by CommandInteraction.OptionsMap. Use its accessors to read value with expected type.
+type OptionValue struct {
+	Type  OptionType
+	Value any // string, float64 (double or integer) or bool
+}
+
+// Returns value of string option or snowflake (as string) of user, channel, role, mentionable & attachment options.
+func (ov OptionValue) AsString() (string, bool) {
+	value, ok := ov.Value.(string)
+	return value, ok
+}
+
+// Returns value of integer option. Discord sends all numbers as doubles so it's also fine to use it for number options without fraction.
+func (ov OptionValue) AsInt() (int64, bool) {
+	value, ok := ov.Value.(float64)
+	return int64(value), ok
+}
+
+func (ov OptionValue) AsFloat() (float64, bool) {
+	value, ok := ov.Value.(float64)
+	return value, ok
+}
+
+func (ov OptionValue) AsBool() (bool, bool) {
+	value, ok := ov.Value.(bool)
+	return value, ok
+}
+
+// Returns id of user, channel, role, mentionable or attachment option.
+func (ov OptionValue) AsSnowflake() (Snowflake, bool) {
+	value, ok := ov.Value.(string)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := StringToSnowflake(value)
+	return id, err == nil
+}
+
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-resolved-data-structure
 type InteractionDataResolved struct {
-	Users    map[Snowflake]*User           `json:"users,omitempty"`
-	Members  map[Snowflake]*Member         `json:"members,omitempty"`
-	Roles    map[Snowflake]*Role           `json:"roles,omitempty"`
-	Channels map[Snowflake]*PartialChannel `json:"channels,omitempty"`
+	Users       map[Snowflake]*User           `json:"users,omitempty"`
+	Members     map[Snowflake]*Member         `json:"members,omitempty"`
+	Roles       map[Snowflake]*Role           `json:"roles,omitempty"`
+	Channels    map[Snowflake]*PartialChannel `json:"channels,omitempty"`
+	Attachments map[Snowflake]*Attachment     `json:"attachments,omitempty"`
 }
 
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-choice-structure
@@ -116,11 +250,22 @@ type Choice struct {
 	Value             any               `json:"value"`                        // string, float64 (double or integer) or bool
 }
 
+// Component interaction triggered by button click. Use Client.RegisterButton to bind handler receiving this type.
+type ButtonInteraction struct {
+	ComponentInteraction
+}
+
+// Component interaction triggered by select menu submission (any of select menu types).
+// Selected values are available under Data.Values. Use Client.RegisterSelectMenu to bind handler receiving this type.
+type SelectMenuInteraction struct {
+	ComponentInteraction
+}
+
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-message-component-data-structure
 type ComponentInteractionData struct {
-	CustomID string              `json:"custom_id"`
-	Type     ComponentType       `json:"component_type"`
-	Values   []*SelectMenuOption `json:"values,omitempty"`
+	CustomID string        `json:"custom_id"`
+	Type     ComponentType `json:"component_type"`
+	Values   []string      `json:"values,omitempty"` // Values selected by user (only for select menu components). For user, role, mentionable & channel selects those are snowflake ids.
 }
 
 type ModalInteractionData struct {
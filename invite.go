@@ -0,0 +1,67 @@
+package tempest
+
+import (
+	"math"
+	"time"
+)
+
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
+type InviteTargetType uint8
+
+const (
+	STREAM_INVITE_TARGET_TYPE InviteTargetType = iota + 1
+	EMBEDDED_APPLICATION_INVITE_TARGET_TYPE
+)
+
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-structure
+type Invite struct {
+	Code       string           `json:"code"`
+	Guild      *PartialGuild    `json:"guild,omitempty"`
+	Channel    *PartialChannel  `json:"channel"`
+	Inviter    *User            `json:"inviter,omitempty"`
+	TargetType InviteTargetType `json:"target_type,omitempty"`
+	TargetUser *User            `json:"target_user,omitempty"` // User whose stream to display for this voice channel stream invite.
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty"`
+	Uses       uint32           `json:"uses,omitempty"`      // Number of times this invite has been used.
+	MaxUses    uint32           `json:"max_uses,omitempty"`  // Max number of times this invite can be used.
+	MaxAge     uint32           `json:"max_age,omitempty"`   // Duration (in seconds) after which the invite expires.
+	Temporary  bool             `json:"temporary,omitempty"` // Whether this invite only grants temporary membership.
+	CreatedAt  *time.Time       `json:"created_at,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/channel#create-channel-invite-json-params
+//
+// Set either MaxAge or ExpiresAt (not both). When neither is set, invite expires after 24 hours.
+type InviteParams struct {
+	MaxAge              *uint32          `json:"max_age,omitempty"`               // Duration of invite in seconds before expiry, 0 for never (up to 604800 - 7 days).
+	ExpiresAt           *time.Time       `json:"-"`                               // Moment when invite should expire, it's converted to MaxAge (clamped to 7 days). It's a Tempest specific field.
+	MaxUses             uint32           `json:"max_uses,omitempty"`              // Max number of uses, 0 for unlimited (up to 100).
+	Temporary           bool             `json:"temporary,omitempty"`             // Whether this invite only grants temporary membership.
+	Unique              bool             `json:"unique,omitempty"`                // If true, don't try to reuse a similar invite (useful for creating many unique one time use invites).
+	TargetType          InviteTargetType `json:"target_type,omitempty"`           // Type of target for this voice channel invite.
+	TargetUserID        Snowflake        `json:"target_user_id,omitempty"`        // Required if TargetType is STREAM_INVITE_TARGET_TYPE, the user must be streaming in the channel.
+	TargetApplicationID Snowflake        `json:"target_application_id,omitempty"` // Required if TargetType is EMBEDDED_APPLICATION_INVITE_TARGET_TYPE.
+}
+
+// Converts ExpiresAt into MaxAge. Expiry in the past results in the shortest possible invite (1 second).
+func (params InviteParams) resolveMaxAge() (InviteParams, error) {
+	if params.ExpiresAt == nil {
+		return params, nil
+	}
+
+	if params.MaxAge != nil {
+		return params, ErrConflictingInviteExpiry
+	}
+
+	seconds := math.Ceil(time.Until(*params.ExpiresAt).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	} else if seconds > MAX_INVITE_AGE {
+		seconds = MAX_INVITE_AGE
+	}
+
+	maxAge := uint32(seconds)
+	params.MaxAge = &maxAge
+	params.ExpiresAt = nil
+	return params, nil
+}
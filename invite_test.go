@@ -0,0 +1,33 @@
+package tempest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInviteParamsResolveMaxAge(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	params, err := InviteParams{ExpiresAt: &expiresAt}.resolveMaxAge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if params.MaxAge == nil || *params.MaxAge < 3599 || *params.MaxAge > 3600 {
+		t.Errorf("expected max age of about 1 hour, got: %v", params.MaxAge)
+	}
+
+	farAway := time.Now().Add(time.Hour * 24 * 30)
+	if params, _ := (InviteParams{ExpiresAt: &farAway}).resolveMaxAge(); *params.MaxAge != MAX_INVITE_AGE {
+		t.Errorf("expected max age to be clamped to %d, got: %d", MAX_INVITE_AGE, *params.MaxAge)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	if params, _ := (InviteParams{ExpiresAt: &past}).resolveMaxAge(); *params.MaxAge != 1 {
+		t.Errorf("expected past expiry to be clamped to 1s, got: %d", *params.MaxAge)
+	}
+
+	never := uint32(0)
+	if _, err := (InviteParams{MaxAge: &never, ExpiresAt: &expiresAt}).resolveMaxAge(); err != ErrConflictingInviteExpiry {
+		t.Errorf("expected ErrConflictingInviteExpiry, got: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package tempest
+
+import (
+	"strconv"
+	"time"
+)
+
+// https://discord.com/developers/docs/reference#message-formatting
+
+func MentionUser(userID Snowflake) string {
+	return "<@" + userID.String() + ">"
+}
+
+func MentionRole(roleID Snowflake) string {
+	return "<@&" + roleID.String() + ">"
+}
+
+func MentionChannel(channelID Snowflake) string {
+	return "<#" + channelID.String() + ">"
+}
+
+func MentionEmoji(name string, emojiID Snowflake) string {
+	return "<:" + name + ":" + emojiID.String() + ">"
+}
+
+func MentionAnimatedEmoji(name string, emojiID Snowflake) string {
+	return "<a:" + name + ":" + emojiID.String() + ">"
+}
+
+// Formats timestamp as short time, example: "16:20".
+func TimestampShortTime(t time.Time) string {
+	return formatTimestamp(t, "t")
+}
+
+// Formats timestamp as long time, example: "16:20:30".
+func TimestampLongTime(t time.Time) string {
+	return formatTimestamp(t, "T")
+}
+
+// Formats timestamp as short date, example: "20/04/2021".
+func TimestampShortDate(t time.Time) string {
+	return formatTimestamp(t, "d")
+}
+
+// Formats timestamp as long date, example: "20 April 2021".
+func TimestampLongDate(t time.Time) string {
+	return formatTimestamp(t, "D")
+}
+
+// Formats timestamp as short date & time, example: "20 April 2021 16:20". It's Discord's default timestamp style.
+func TimestampShort(t time.Time) string {
+	return formatTimestamp(t, "f")
+}
+
+// Formats timestamp as long date & time, example: "Tuesday, 20 April 2021 16:20".
+func TimestampLong(t time.Time) string {
+	return formatTimestamp(t, "F")
+}
+
+// Formats timestamp as relative time, example: "2 months ago". It's automatically updated by Discord client.
+func TimestampRelative(t time.Time) string {
+	return formatTimestamp(t, "R")
+}
+
+// Each user sees timestamp formatted in their own locale & timezone.
+func formatTimestamp(t time.Time, style string) string {
+	return "<t:" + strconv.FormatInt(t.Unix(), 10) + ":" + style + ">"
+}
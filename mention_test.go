@@ -0,0 +1,29 @@
+package tempest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMention(t *testing.T) {
+	if mention := MentionRole(165511591545143296); mention != "<@&165511591545143296>" {
+		t.Errorf("invalid role mention: %s", mention)
+	}
+
+	if mention := MentionAnimatedEmoji("b1nzy", 123); mention != "<a:b1nzy:123>" {
+		t.Errorf("invalid animated emoji mention: %s", mention)
+	}
+
+	if mention := MentionUser(1); mention != (User{ID: 1}).Mention() {
+		t.Errorf("invalid user mention: %s", mention)
+	}
+
+	timestamp := time.Unix(1618953630, 0)
+	if formatted := TimestampRelative(timestamp); formatted != "<t:1618953630:R>" {
+		t.Errorf("invalid relative timestamp: %s", formatted)
+	}
+
+	if formatted := TimestampLongDate(timestamp); formatted != "<t:1618953630:D>" {
+		t.Errorf("invalid long date timestamp: %s", formatted)
+	}
+}
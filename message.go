@@ -1,8 +1,13 @@
 package tempest
 
 import (
+	"fmt"
+	"net/url"
 	"strconv"
 	"time"
+	"unicode/utf8"
+
+	"github.com/sugawarayuuta/sonnet"
 )
 
 // https://discord.com/developers/docs/resources/channel#channel-object-channel-types
@@ -32,6 +37,27 @@ func (ct ChannelType) MarshalJSON() (p []byte, err error) {
 	return []byte(buf), nil
 }
 
+// https://discord.com/developers/docs/resources/channel#message-object-message-flags
+//
+// Only SUPPRESS_EMBEDS_MESSAGE_FLAG and SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG (or both, combined with bitwise OR) can be set when sending regular message.
+// Interaction replies & follow ups additionally accept EPHEMERAL_MESSAGE_FLAG. All other flags are read-only and set by Discord.
+const (
+	CROSSPOSTED_MESSAGE_FLAG                            uint64 = 1 << iota // Message has been published to subscribed channels (via Channel Following).
+	IS_CROSSPOST_MESSAGE_FLAG                                              // Message originated from a message in another channel (via Channel Following).
+	SUPPRESS_EMBEDS_MESSAGE_FLAG                                           // Do not include any embeds (link previews) when serializing this message. Same as setting Message.SuppressEmbeds.
+	SOURCE_MESSAGE_DELETED_MESSAGE_FLAG                                    // Source message for this crosspost has been deleted.
+	URGENT_MESSAGE_FLAG                                                    // Message came from the urgent message system.
+	HAS_THREAD_MESSAGE_FLAG                                                // Message has an associated thread, with the same id as the message.
+	EPHEMERAL_MESSAGE_FLAG                                                 // Message is only visible to the user who invoked the interaction.
+	LOADING_MESSAGE_FLAG                                                   // Message is an interaction response and the bot is "thinking".
+	FAILED_TO_MENTION_SOME_ROLES_IN_THREAD_MESSAGE_FLAG                    // Message failed to mention some roles and add their members to the thread.
+	_
+	_
+	_
+	SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG // Message will not trigger push and desktop notifications.
+	IS_VOICE_MESSAGE_MESSAGE_FLAG       // Message is a voice message.
+)
+
 // https://discord.com/developers/docs/resources/sticker#sticker-object-sticker-format-types
 type StickerFormatType uint8
 
@@ -178,17 +204,189 @@ type Message struct {
 	MentionRoles      []*Snowflake        `json:"mention_roles"`
 	MentionChannels   []*ChannelMention   `json:"mention_channels,omitempty"`
 	Embeds            []*Embed            `json:"embeds"`
+	Attachments       []*Attachment       `json:"attachments,omitempty"`
 	Reactions         []*Reaction         `json:"reactions,omitempty"`
 	Pinned            bool                `json:"pinned"`
 	WebhookID         Snowflake           `json:"webhook_id,omitempty"`
 	Type              uint                `json:"type,omitempty"` // https://discord.com/developers/docs/resources/channel#message-object-message-types
 	ApplicationID     Snowflake           `json:"application_id,omitempty"`
 	MessageReference  *MessageReference   `json:"message_reference,omitempty"`
-	Flags             uint64              `json:"flags,omitempty"`
+	Flags             uint64              `json:"flags,omitempty"` // Bit set of message flags (see SUPPRESS_EMBEDS_MESSAGE_FLAG and others).
 	ReferencedMessage *Message            `json:"referenced_message,omitempty"`
 	Interaction       *MessageInteraction `json:"interaction,omitempty"`
 	Components        []*ComponentRow     `json:"components,omitempty"`
 	StickerItems      []*StickerItem      `json:"sticker_items,omitempty"`
+	Poll              *Poll               `json:"poll,omitempty"`
+
+	// Whether Discord should skip generating link previews for urls in message content. It sets SUPPRESS_EMBEDS_MESSAGE_FLAG
+	// on sent message and doesn't affect embeds provided in Embeds field.
+	SuppressEmbeds bool `json:"-"`
+}
+
+func (msg Message) MarshalJSON() ([]byte, error) {
+	type plainMessage Message // Alias without methods to avoid infinite recursion.
+	if msg.SuppressEmbeds {
+		msg.Flags |= SUPPRESS_EMBEDS_MESSAGE_FLAG
+	}
+
+	return sonnet.Marshal(plainMessage(msg))
+}
+
+// https://discord.com/developers/docs/resources/channel#attachment-object-attachment-structure
+type Attachment struct {
+	ID          Snowflake `json:"id"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description,omitempty"`
+	ContentType string    `json:"content_type,omitempty"` // Attachment's media type.
+	Size        uint      `json:"size"`                   // Size of file in bytes.
+	URL         string    `json:"url"`
+	ProxyURL    string    `json:"proxy_url"`
+	Height      uint32    `json:"height,omitempty"` // Height of file (if image).
+	Width       uint32    `json:"width,omitempty"`  // Width of file (if image).
+	Ephemeral   bool      `json:"ephemeral,omitempty"`
+}
+
+// File to upload together with message. Reference it in embeds with "attachment://<name>" url.
+type File struct {
+	Name        string // File name with extension, example: "avatar.png".
+	ContentType string // Media type of file, example: "image/png". Required by ValidateFiles. (default: "application/octet-stream")
+	Data        []byte
+}
+
+// Checks files before uploading them, so requests that Discord would reject don't waste time on multipart upload.
+// Use maxUploadSize to set guild's upload limit (like MAX_BOOSTED_UPLOAD_SIZE), 0 falls back to MAX_UPLOAD_SIZE.
+// Errors about specific file include its index & name and wrap ErrFileNameMissing, ErrFileContentTypeMissing or ErrFileTooLarge.
+func ValidateFiles(files []File, maxUploadSize int64) error {
+	if len(files) > MAX_MESSAGE_FILES {
+		return ErrTooManyFiles
+	}
+
+	if maxUploadSize <= 0 {
+		maxUploadSize = MAX_UPLOAD_SIZE
+	}
+
+	var total int64
+	for i, file := range files {
+		if file.Name == "" {
+			return fmt.Errorf("file #%d: %w", i, ErrFileNameMissing)
+		}
+
+		if file.ContentType == "" {
+			return fmt.Errorf("file #%d (%q): %w", i, file.Name, ErrFileContentTypeMissing)
+		}
+
+		size := int64(len(file.Data))
+		if size > maxUploadSize {
+			return fmt.Errorf("file #%d (%q) has %d bytes, limit is %d: %w", i, file.Name, size, maxUploadSize, ErrFileTooLarge)
+		}
+
+		total += size
+	}
+
+	if total > maxUploadSize {
+		return fmt.Errorf("files have %d bytes, limit is %d: %w", total, maxUploadSize, ErrFilesTooLarge)
+	}
+
+	return nil
+}
+
+// Checks message against Discord's content, embed & component limits, so invalid messages fail fast without making any request.
+func (msg Message) Validate() error {
+	if utf8.RuneCountInString(msg.Content) > MAX_MESSAGE_CONTENT_LENGTH {
+		return ErrContentTooLong
+	}
+
+	for _, embed := range msg.Embeds {
+		if embed != nil && utf8.RuneCountInString(embed.Description) > MAX_EMBED_DESCRIPTION_LENGTH {
+			return ErrEmbedTooLong
+		}
+	}
+
+	if EmbedsCharCount(msg.Embeds) > MAX_EMBEDS_CHARACTER_COUNT {
+		return ErrEmbedTooLong
+	}
+
+	if len(msg.Components) > MAX_MESSAGE_COMPONENT_ROWS {
+		return ErrTooManyRows
+	}
+
+	for _, row := range msg.Components {
+		if row != nil && !row.fits() {
+			return ErrRowFull
+		}
+	}
+
+	return nil
+}
+
+// Checks embed's description length and its image & thumbnail urls (https with valid host, or attachment:// reference to uploaded file).
+// Use it before sending embed to avoid round-trip that ends with 400 error.
+func (embed Embed) Validate() error {
+	if utf8.RuneCountInString(embed.Description) > MAX_EMBED_DESCRIPTION_LENGTH {
+		return ErrEmbedTooLong
+	}
+
+	if embed.Thumbnail != nil && !validEmbedURL(embed.Thumbnail.URL) {
+		return ErrInvalidEmbedURL
+	}
+
+	if embed.Image != nil && !validEmbedURL(embed.Image.URL) {
+		return ErrInvalidEmbedURL
+	}
+
+	return nil
+}
+
+func validEmbedURL(rawURL string) bool {
+	if len(rawURL) > MAX_EMBED_URL_LENGTH {
+		return false
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return parsed.Hostname() != ""
+	case "attachment":
+		return parsed.Host != ""
+	}
+
+	return false
+}
+
+// Counts all characters Discord includes in embed's limit (title, description, author name, footer text and all field names & values).
+func (embed Embed) CharCount() int {
+	count := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+	if embed.Author != nil {
+		count += utf8.RuneCountInString(embed.Author.Name)
+	}
+
+	if embed.Footer != nil {
+		count += utf8.RuneCountInString(embed.Footer.Text)
+	}
+
+	for _, field := range embed.Fields {
+		if field != nil {
+			count += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		}
+	}
+
+	return count
+}
+
+// Sums characters of all embeds, Discord allows up to 6000 (MAX_EMBEDS_CHARACTER_COUNT) per message.
+func EmbedsCharCount(embeds []*Embed) int {
+	total := 0
+	for _, embed := range embeds {
+		if embed != nil {
+			total += embed.CharCount()
+		}
+	}
+	return total
 }
 
 // https://discord.com/developers/docs/resources/channel#message-reference-object-message-reference-structure
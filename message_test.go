@@ -0,0 +1,224 @@
+package tempest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+func TestMessageValidate(t *testing.T) {
+	t.Run("empty content", func(t *testing.T) {
+		if err := (Message{}).Validate(); err != nil {
+			t.Errorf("empty message should pass validation, got: %s", err)
+		}
+	})
+
+	t.Run("content at limit", func(t *testing.T) {
+		msg := Message{Content: strings.Repeat("a", MAX_MESSAGE_CONTENT_LENGTH)}
+		if err := msg.Validate(); err != nil {
+			t.Errorf("message with exactly %d characters should pass validation, got: %s", MAX_MESSAGE_CONTENT_LENGTH, err)
+		}
+	})
+
+	t.Run("content over limit", func(t *testing.T) {
+		msg := Message{Content: strings.Repeat("a", MAX_MESSAGE_CONTENT_LENGTH+1)}
+		if err := msg.Validate(); err != ErrContentTooLong {
+			t.Errorf("expected ErrContentTooLong, got: %v", err)
+		}
+	})
+
+	t.Run("content counts characters, not bytes", func(t *testing.T) {
+		msg := Message{Content: strings.Repeat("ł", MAX_MESSAGE_CONTENT_LENGTH)}
+		if err := msg.Validate(); err != nil {
+			t.Errorf("message with exactly %d multi-byte characters should pass validation, got: %s", MAX_MESSAGE_CONTENT_LENGTH, err)
+		}
+	})
+
+	t.Run("embeds at limit", func(t *testing.T) {
+		msg := Message{Embeds: []*Embed{
+			{Title: strings.Repeat("a", 1000), Description: strings.Repeat("a", 4000)},
+			{Fields: []*EmbedField{{Name: strings.Repeat("a", 500), Value: strings.Repeat("a", 500)}}},
+		}}
+		if err := msg.Validate(); err != nil {
+			t.Errorf("embeds with exactly %d characters should pass validation, got: %s", MAX_EMBEDS_CHARACTER_COUNT, err)
+		}
+	})
+
+	t.Run("embeds over limit", func(t *testing.T) {
+		msg := Message{Embeds: []*Embed{
+			{Title: strings.Repeat("a", 1000), Description: strings.Repeat("a", 4000)},
+			{Footer: &EmbedFooter{Text: strings.Repeat("a", 1001)}},
+		}}
+		if err := msg.Validate(); err != ErrEmbedTooLong {
+			t.Errorf("expected ErrEmbedTooLong, got: %v", err)
+		}
+	})
+
+	t.Run("embed description over limit", func(t *testing.T) {
+		msg := Message{Embeds: []*Embed{{Description: strings.Repeat("a", MAX_EMBED_DESCRIPTION_LENGTH+1)}}}
+		if err := msg.Validate(); err != ErrEmbedTooLong {
+			t.Errorf("expected ErrEmbedTooLong, got: %v", err)
+		}
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		msg := Message{Components: make([]*ComponentRow, MAX_MESSAGE_COMPONENT_ROWS+1)}
+		if err := msg.Validate(); err != ErrTooManyRows {
+			t.Errorf("expected ErrTooManyRows, got: %v", err)
+		}
+	})
+
+	t.Run("overflowing row", func(t *testing.T) {
+		msg := Message{Components: []*ComponentRow{{
+			Type:       ROW_COMPONENT_TYPE,
+			Components: []*Component{{Type: SELECT_MENU_COMPONENT_TYPE}, {Type: BUTTON_COMPONENT_TYPE}},
+		}}}
+		if err := msg.Validate(); err != ErrRowFull {
+			t.Errorf("expected ErrRowFull, got: %v", err)
+		}
+	})
+}
+
+func TestEmbedCharCount(t *testing.T) {
+	embed := Embed{
+		Title:       "Title",
+		Description: "Zażółć",
+		URL:         "https://example.com", // Not counted by Discord.
+		Author:      &EmbedAuthor{Name: "Author"},
+		Footer:      &EmbedFooter{Text: "Footer"},
+		Fields:      []*EmbedField{{Name: "a", Value: "bc"}, nil},
+	}
+
+	if count := embed.CharCount(); count != 26 {
+		t.Errorf("expected 26 characters, got: %d", count)
+	}
+
+	if total := EmbedsCharCount([]*Embed{&embed, nil, {Title: "1234"}}); total != 30 {
+		t.Errorf("expected 30 characters in total, got: %d", total)
+	}
+}
+
+func TestEmbedValidate(t *testing.T) {
+	valid := []Embed{
+		{},
+		{Thumbnail: &EmbedThumbnail{URL: "https://cdn.discordapp.com/embed/avatars/0.png"}},
+		{Image: &EmbedImage{URL: "attachment://chart.png"}},
+	}
+
+	for _, embed := range valid {
+		if err := embed.Validate(); err != nil {
+			t.Errorf("expected embed to pass validation, got: %s (%+v)", err, embed)
+		}
+	}
+
+	invalid := []Embed{
+		{Thumbnail: &EmbedThumbnail{URL: "http://example.com/image.png"}},
+		{Image: &EmbedImage{URL: "https:///image.png"}},
+		{Image: &EmbedImage{URL: "https://example.com/" + strings.Repeat("a", MAX_EMBED_URL_LENGTH)}},
+		{Image: &EmbedImage{URL: ""}},
+	}
+
+	for _, embed := range invalid {
+		if err := embed.Validate(); err != ErrInvalidEmbedURL {
+			t.Errorf("expected ErrInvalidEmbedURL, got: %v", err)
+		}
+	}
+}
+
+func TestActionRowBuilder(t *testing.T) {
+	row := NewActionRow()
+	for i := 0; i < MAX_ROW_BUTTONS; i++ {
+		if err := row.AddButton(Component{CustomID: "button", Style: uint8(PRIMARY_BUTTON_STYLE)}); err != nil {
+			t.Fatalf("failed to add button #%d: %s", i+1, err)
+		}
+	}
+
+	if err := row.AddButton(Component{CustomID: "button"}); err != ErrRowFull {
+		t.Errorf("expected ErrRowFull after adding 6th button, got: %v", err)
+	}
+
+	if err := row.AddSelectMenu(Component{CustomID: "menu"}); err != ErrRowFull {
+		t.Errorf("expected ErrRowFull after adding select menu to row with buttons, got: %v", err)
+	}
+
+	built := row.Build()
+	if built.Type != ROW_COMPONENT_TYPE || len(built.Components) != MAX_ROW_BUTTONS {
+		t.Error("built row has invalid type or components")
+	}
+
+	menuRow := NewActionRow()
+	if err := menuRow.AddSelectMenu(Component{CustomID: "menu"}); err != nil {
+		t.Fatalf("failed to add select menu: %s", err)
+	}
+
+	if err := menuRow.AddButton(Component{CustomID: "button"}); err != ErrRowFull {
+		t.Errorf("expected ErrRowFull after adding button to row with select menu, got: %v", err)
+	}
+}
+
+// Client has no Rest so any attempt to make request would panic.
+func TestSendMessageValidation(t *testing.T) {
+	client := Client{}
+	_, err := client.SendMessage(0, Message{Content: strings.Repeat("a", MAX_MESSAGE_CONTENT_LENGTH+1)})
+	if err != ErrContentTooLong {
+		t.Errorf("expected ErrContentTooLong, got: %v", err)
+	}
+}
+
+func TestMessageSuppressEmbeds(t *testing.T) {
+	raw, err := sonnet.Marshal(Message{Content: "https://example.com", SuppressEmbeds: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(raw), `"flags":4`) {
+		t.Errorf("expected suppress embeds flag to be set, got: %s", raw)
+	}
+
+	raw, err = sonnet.Marshal(&Message{Content: "https://example.com", Flags: SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG, SuppressEmbeds: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(raw), `"flags":4100`) {
+		t.Errorf("expected suppress embeds flag to be combined with other flags, got: %s", raw)
+	}
+
+	raw, err = sonnet.Marshal(Message{Content: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(raw), `"flags"`) {
+		t.Errorf("expected no flags by default, got: %s", raw)
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	image := File{Name: "avatar.png", ContentType: "image/png", Data: make([]byte, 1024)}
+	if err := ValidateFiles([]File{image, image}, 0); err != nil {
+		t.Errorf("valid files should pass validation, got: %s", err)
+	}
+
+	if err := ValidateFiles([]File{image, {Name: "notes.txt", Data: []byte("a")}}, 0); !errors.Is(err, ErrFileContentTypeMissing) || !strings.Contains(err.Error(), `#1 ("notes.txt")`) {
+		t.Errorf("expected missing content type error naming file, got: %v", err)
+	}
+
+	if err := ValidateFiles([]File{{ContentType: "image/png"}}, 0); !errors.Is(err, ErrFileNameMissing) {
+		t.Errorf("expected missing name error, got: %v", err)
+	}
+
+	if err := ValidateFiles([]File{{Name: "video.mp4", ContentType: "video/mp4", Data: make([]byte, MAX_UPLOAD_SIZE+1)}}, 0); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected file too large error, got: %v", err)
+	}
+
+	if err := ValidateFiles([]File{image, image}, 1500); !errors.Is(err, ErrFilesTooLarge) {
+		t.Errorf("expected total size error, got: %v", err)
+	}
+
+	if err := ValidateFiles(make([]File, MAX_MESSAGE_FILES+1), 0); err != ErrTooManyFiles {
+		t.Errorf("expected too many files error, got: %v", err)
+	}
+}
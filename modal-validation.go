@@ -0,0 +1,89 @@
+package tempest
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Constraints for single text input of submitted modal, used by ModalInteraction.Validate.
+type FieldRule struct {
+	Required bool           // Whether field can't be left empty.
+	MinLen   uint           // Min number of characters (checked only for non-empty values).
+	MaxLen   uint           // Max number of characters, use 0 for no limit.
+	Pattern  *regexp.Regexp // Optional pattern that non-empty value has to match.
+}
+
+// Single violation of FieldRule reported by ModalInteraction.Validate.
+type FieldError struct {
+	CustomID string // Custom id of text input that failed validation.
+	Message  string // Human readable reason, safe to show to user.
+}
+
+func (fe FieldError) Error() string {
+	return fe.CustomID + ": " + fe.Message
+}
+
+// Checks submitted text inputs against rules (keyed by text input custom ids) and returns all violations.
+// Errors are listed in order of modal's fields, rules for fields missing in submission are checked last (as empty values).
+// It returns empty slice when everything is valid, so it's easy to build ephemeral error reply from its result.
+func (itx ModalInteraction) Validate(rules map[string]FieldRule) []FieldError {
+	errs := make([]FieldError, 0)
+	checked := make(map[string]bool, len(rules))
+
+	for _, row := range itx.Data.Components {
+		for _, component := range row.Components {
+			rule, available := rules[component.CustomID]
+			if !available || checked[component.CustomID] {
+				continue
+			}
+
+			checked[component.CustomID] = true
+			if message := rule.check(component.Value); message != "" {
+				errs = append(errs, FieldError{CustomID: component.CustomID, Message: message})
+			}
+		}
+	}
+
+	missing := make([]string, 0)
+	for customID := range rules {
+		if !checked[customID] {
+			missing = append(missing, customID)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, customID := range missing {
+		if message := rules[customID].check(""); message != "" {
+			errs = append(errs, FieldError{CustomID: customID, Message: message})
+		}
+	}
+
+	return errs
+}
+
+// Returns reason why value breaks rule or empty string when it's valid.
+func (rule FieldRule) check(value string) string {
+	if value == "" {
+		if rule.Required {
+			return "field is required"
+		}
+		return ""
+	}
+
+	length := uint(utf8.RuneCountInString(value))
+	if length < rule.MinLen {
+		return "must be at least " + strconv.FormatUint(uint64(rule.MinLen), 10) + " characters long"
+	}
+
+	if rule.MaxLen != 0 && length > rule.MaxLen {
+		return "must be at most " + strconv.FormatUint(uint64(rule.MaxLen), 10) + " characters long"
+	}
+
+	if rule.Pattern != nil && !rule.Pattern.MatchString(value) {
+		return "has invalid format"
+	}
+
+	return ""
+}
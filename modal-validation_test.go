@@ -0,0 +1,33 @@
+package tempest
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestModalValidate(t *testing.T) {
+	itx := ModalInteraction{Data: ModalInteractionData{Components: []ComponentRow{
+		{Components: []*Component{{CustomID: "title", Value: "Hi"}}},
+		{Components: []*Component{{CustomID: "email", Value: "not an email"}}},
+		{Components: []*Component{{CustomID: "notes", Value: ""}}},
+	}}}
+
+	errs := itx.Validate(map[string]FieldRule{
+		"title":  {Required: true, MinLen: 3, MaxLen: 50},
+		"email":  {Required: true, Pattern: regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)},
+		"notes":  {MaxLen: 100},
+		"reason": {Required: true},
+	})
+
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 field errors, got: %+v", errs)
+	}
+
+	if errs[0].CustomID != "title" || errs[1].CustomID != "email" || errs[2].CustomID != "reason" || errs[2].Message != "field is required" {
+		t.Errorf("unexpected field errors: %+v", errs)
+	}
+
+	if errs := itx.Validate(map[string]FieldRule{"title": {Required: true, MaxLen: 2}}); len(errs) != 0 {
+		t.Errorf("expected valid submission, got: %+v", errs)
+	}
+}
@@ -0,0 +1,57 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-onboarding-mode
+type OnboardingMode uint8
+
+const (
+	DEFAULT_ONBOARDING_MODE  OnboardingMode = iota // Counts only Default Channels towards constraints.
+	ADVANCED_ONBOARDING_MODE                       // Counts Default Channels and Questions towards constraints.
+)
+
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-prompt-types
+type PromptType uint8
+
+const (
+	MULTIPLE_CHOICE_PROMPT_TYPE PromptType = iota
+	DROPDOWN_PROMPT_TYPE
+)
+
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-guild-onboarding-structure
+type GuildOnboarding struct {
+	GuildID           Snowflake          `json:"guild_id"`
+	Prompts           []OnboardingPrompt `json:"prompts"`
+	DefaultChannelIDs []Snowflake        `json:"default_channel_ids"` // Channels that members get opted into automatically.
+	Enabled           bool               `json:"enabled"`
+	Mode              OnboardingMode     `json:"mode"`
+}
+
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-onboarding-prompt-structure
+type OnboardingPrompt struct {
+	ID           Snowflake      `json:"id"`
+	Type         PromptType     `json:"type"`
+	Options      []PromptOption `json:"options"`
+	Title        string         `json:"title"`
+	SingleSelect bool           `json:"single_select"` // Whether users are limited to selecting one option for the prompt.
+	Required     bool           `json:"required"`      // Whether the prompt is required before a user completes the onboarding flow.
+	InOnboarding bool           `json:"in_onboarding"` // Whether the prompt is present in the onboarding flow. If false, the prompt will only appear in the Channels & Roles tab.
+}
+
+// https://discord.com/developers/docs/resources/guild#guild-onboarding-object-prompt-option-structure
+type PromptOption struct {
+	ID          Snowflake     `json:"id"`
+	ChannelIDs  []Snowflake   `json:"channel_ids"` // Channels a member is added to when the option is selected.
+	RoleIDs     []Snowflake   `json:"role_ids"`    // Roles assigned to a member when the option is selected.
+	Emoji       *PartialEmoji `json:"emoji,omitempty"`
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-onboarding-json-params
+//
+// Leave fields as <nil> to keep their current values.
+type GuildOnboardingParams struct {
+	Prompts           []OnboardingPrompt `json:"prompts,omitempty"`
+	DefaultChannelIDs []Snowflake        `json:"default_channel_ids,omitempty"`
+	Enabled           *bool              `json:"enabled,omitempty"`
+	Mode              *OnboardingMode    `json:"mode,omitempty"`
+}
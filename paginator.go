@@ -0,0 +1,107 @@
+package tempest
+
+import (
+	"errors"
+	"time"
+)
+
+// Multi page message with "Previous" & "Next" buttons. Create it with NewPaginator function.
+// Paginator uses Client.AwaitComponent under the hood so its buttons stop working (and get disabled) after timeout.
+type Paginator struct {
+	pages   []ResponseMessageData
+	timeout time.Duration
+}
+
+// Timeout is capped at INTERACTION_TOKEN_LIFETIME, as paginator can't edit its message after interaction token expires.
+func NewPaginator(pages []ResponseMessageData, timeout time.Duration) *Paginator {
+	if timeout > INTERACTION_TOKEN_LIFETIME {
+		timeout = INTERACTION_TOKEN_LIFETIME
+	}
+
+	return &Paginator{
+		pages:   pages,
+		timeout: timeout,
+	}
+}
+
+// Replies to command interaction with first page and starts listening for button clicks in background.
+// Each page should leave room for one extra action row (used by paginator's buttons).
+func (paginator *Paginator) Send(itx CommandInteraction) error {
+	if len(paginator.pages) == 0 {
+		return errors.New("paginator needs at least one page")
+	}
+
+	if len(paginator.pages) == 1 {
+		return itx.SendReply(paginator.pages[0], false)
+	}
+
+	previousID, nextID := "tempest-paginator-"+itx.ID.String()+"-previous", "tempest-paginator-"+itx.ID.String()+"-next"
+	signalChannel, closeFunction, err := itx.Client.AwaitComponent([]string{previousID, nextID}, paginator.timeout)
+	if err != nil {
+		return err
+	}
+
+	err = itx.SendReply(paginator.page(0, previousID, nextID, false), false)
+	if err != nil {
+		closeFunction() // Releases custom ids right away instead of holding them until timeout.
+		return err
+	}
+
+	go func() {
+		index := 0
+		for click := range signalChannel {
+			if click == nil {
+				break
+			}
+
+			if click.Data.CustomID == previousID && index > 0 {
+				index--
+			} else if click.Data.CustomID == nextID && index < len(paginator.pages)-1 {
+				index++
+			}
+
+			paginator.edit(itx, paginator.page(index, previousID, nextID, false))
+		}
+
+		paginator.edit(itx, paginator.page(index, previousID, nextID, true))
+	}()
+
+	return nil
+}
+
+// Edits paginator's message. There's no caller to return error to (it runs in background), so failures are only logged.
+func (paginator *Paginator) edit(itx CommandInteraction, page ResponseMessageData) {
+	err := itx.EditReply(page, false)
+	if err != nil && itx.Client.logger != nil {
+		itx.Client.logger.Printf("ERROR failed to edit paginator message id=%s: %s", itx.ID, err)
+	}
+}
+
+// Returns copy of page at index with appended navigation row. Buttons are disabled on first/last page or when expired = true.
+func (paginator *Paginator) page(index int, previousID string, nextID string, expired bool) ResponseMessageData {
+	page := paginator.pages[index]
+	components := make([]*ComponentRow, len(page.Components), len(page.Components)+1)
+	copy(components, page.Components)
+
+	page.Components = append(components, &ComponentRow{
+		Type: ROW_COMPONENT_TYPE,
+		Components: []*Component{
+			{
+				Type:     BUTTON_COMPONENT_TYPE,
+				CustomID: previousID,
+				Style:    uint8(SECONDARY_BUTTON_STYLE),
+				Label:    "Previous",
+				Disabled: expired || index == 0,
+			},
+			{
+				Type:     BUTTON_COMPONENT_TYPE,
+				CustomID: nextID,
+				Style:    uint8(SECONDARY_BUTTON_STYLE),
+				Label:    "Next",
+				Disabled: expired || index == len(paginator.pages)-1,
+			},
+		},
+	})
+
+	return page
+}
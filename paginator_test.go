@@ -0,0 +1,16 @@
+package tempest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPaginatorTimeout(t *testing.T) {
+	if paginator := NewPaginator(nil, time.Hour); paginator.timeout != INTERACTION_TOKEN_LIFETIME {
+		t.Errorf("expected timeout to be capped at interaction token lifetime, got: %s", paginator.timeout)
+	}
+
+	if paginator := NewPaginator(nil, time.Minute); paginator.timeout != time.Minute {
+		t.Errorf("expected shorter timeout to stay unchanged, got: %s", paginator.timeout)
+	}
+}
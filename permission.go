@@ -0,0 +1,198 @@
+package tempest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sugawarayuuta/sonnet"
+)
+
+// https://discord.com/developers/docs/topics/permissions#permissions-bitwise-permission-flags
+const (
+	CREATE_INSTANT_INVITE_PERMISSION_FLAG uint64 = 1 << iota
+	KICK_MEMBERS_PERMISSION_FLAG
+	BAN_MEMBERS_PERMISSION_FLAG
+	ADMINISTRATOR_PERMISSION_FLAG // Allows all permissions and bypasses channel permission overwrites.
+	MANAGE_CHANNELS_PERMISSION_FLAG
+	MANAGE_GUILD_PERMISSION_FLAG
+	ADD_REACTIONS_PERMISSION_FLAG
+	VIEW_AUDIT_LOG_PERMISSION_FLAG
+	PRIORITY_SPEAKER_PERMISSION_FLAG
+	STREAM_PERMISSION_FLAG
+	VIEW_CHANNEL_PERMISSION_FLAG
+	SEND_MESSAGES_PERMISSION_FLAG
+	SEND_TTS_MESSAGES_PERMISSION_FLAG
+	MANAGE_MESSAGES_PERMISSION_FLAG
+	EMBED_LINKS_PERMISSION_FLAG
+	ATTACH_FILES_PERMISSION_FLAG
+	READ_MESSAGE_HISTORY_PERMISSION_FLAG
+	MENTION_EVERYONE_PERMISSION_FLAG
+	USE_EXTERNAL_EMOJIS_PERMISSION_FLAG
+	VIEW_GUILD_INSIGHTS_PERMISSION_FLAG
+	CONNECT_PERMISSION_FLAG
+	SPEAK_PERMISSION_FLAG
+	MUTE_MEMBERS_PERMISSION_FLAG
+	DEAFEN_MEMBERS_PERMISSION_FLAG
+	MOVE_MEMBERS_PERMISSION_FLAG
+	USE_VAD_PERMISSION_FLAG
+	CHANGE_NICKNAME_PERMISSION_FLAG
+	MANAGE_NICKNAMES_PERMISSION_FLAG
+	MANAGE_ROLES_PERMISSION_FLAG
+	MANAGE_WEBHOOKS_PERMISSION_FLAG
+	MANAGE_GUILD_EXPRESSIONS_PERMISSION_FLAG
+	USE_APPLICATION_COMMANDS_PERMISSION_FLAG
+	REQUEST_TO_SPEAK_PERMISSION_FLAG
+	MANAGE_EVENTS_PERMISSION_FLAG
+	MANAGE_THREADS_PERMISSION_FLAG
+	CREATE_PUBLIC_THREADS_PERMISSION_FLAG
+	CREATE_PRIVATE_THREADS_PERMISSION_FLAG
+	USE_EXTERNAL_STICKERS_PERMISSION_FLAG
+	SEND_MESSAGES_IN_THREADS_PERMISSION_FLAG
+	USE_EMBEDDED_ACTIVITIES_PERMISSION_FLAG
+	MODERATE_MEMBERS_PERMISSION_FLAG
+	VIEW_CREATOR_MONETIZATION_ANALYTICS_PERMISSION_FLAG
+	USE_SOUNDBOARD_PERMISSION_FLAG
+	_
+	_
+	USE_EXTERNAL_SOUNDS_PERMISSION_FLAG
+	SEND_VOICE_MESSAGES_PERMISSION_FLAG
+)
+
+// Checks whether member that triggered interaction has all requested permission flags (combine multiple flags with bitwise OR).
+// Members with administrator permission always pass. It'll return false for interactions from DM channels as there's no member data.
+func (client *Client) UserHasPermission(itx CommandInteraction, permission uint64) bool {
+	if itx.Member == nil {
+		return false
+	}
+
+	if itx.Member.PermissionFlags&ADMINISTRATOR_PERMISSION_FLAG != 0 {
+		return true
+	}
+
+	return itx.Member.PermissionFlags&permission == permission
+}
+
+// Checks whether app has all requested permission flags (combine multiple flags with bitwise OR) in selected guild channel.
+// It fetches app's bot user, guild (for its owner & roles), app's member and channel (parent channel for threads), then resolves
+// permissions same way Discord does (see ComputePermissions). Use 0 as channelID to check guild level permissions only. It makes up to
+// 5 requests, so cache its result when possible - for interactions prefer already computed CommandInteraction.PermissionFlags.
+func (client *Client) BotHasPermissions(guildID Snowflake, channelID Snowflake, permissions uint64) (bool, error) {
+	bot, err := client.FetchBotUser()
+	if err != nil {
+		return false, err
+	}
+
+	raw, _, err := client.Rest.Request(http.MethodGet, "/guilds/"+guildID.String(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	guild := guildPermissionData{}
+	err = sonnet.Unmarshal(raw, &guild)
+	if err != nil {
+		return false, errors.New("failed to parse received data from discord")
+	}
+
+	// Owner has all permissions, no need to check member and channel.
+	if guild.OwnerID == bot.ID {
+		return true, nil
+	}
+
+	member, err := client.FetchMember(guildID, bot.ID)
+	if err != nil {
+		return false, err
+	}
+
+	var overwrites []PermissionOverwrite
+	if !channelID.IsZero() {
+		channel, err := client.FetchChannel(channelID)
+		if err != nil {
+			return false, err
+		}
+
+		// Threads inherit permissions of their parent channel.
+		if isThreadChannel(channel.Type) && !channel.ParentID.IsZero() {
+			channel, err = client.FetchChannel(channel.ParentID)
+			if err != nil {
+				return false, err
+			}
+		}
+		overwrites = channel.PermissionOverwrites
+	}
+
+	if member.User == nil {
+		member.User = &bot
+	}
+
+	computed := ComputePermissions(guildID, guild.OwnerID, member, guild.Roles, overwrites)
+	return computed&permissions == permissions, nil
+}
+
+// Used only for partial JSON parsing of guild object (only fields needed to compute permissions).
+type guildPermissionData struct {
+	OwnerID Snowflake `json:"owner_id"`
+	Roles   []Role    `json:"roles"`
+}
+
+// Computes member's permissions following Discord's algorithm: guild owner gets all permissions, otherwise @everyone role and
+// member's roles are combined, administrators get all permissions, then channel overwrites are applied (@everyone, roles and finally
+// member specific one). Members in timeout keep only VIEW_CHANNEL_PERMISSION_FLAG & READ_MESSAGE_HISTORY_PERMISSION_FLAG.
+// Pass <nil> overwrites for guild level permissions.
+//
+// https://discord.com/developers/docs/topics/permissions#permission-overwrites
+func ComputePermissions(guildID Snowflake, ownerID Snowflake, member Member, roles []Role, overwrites []PermissionOverwrite) uint64 {
+	var userID Snowflake
+	if member.User != nil {
+		userID = member.User.ID
+	}
+
+	if !userID.IsZero() && userID == ownerID {
+		return ^uint64(0)
+	}
+
+	var permissions uint64
+	for _, role := range roles {
+		if role.ID == guildID || member.HasRole(role.ID) {
+			permissions |= role.PermissionFlags
+		}
+	}
+
+	if permissions&ADMINISTRATOR_PERMISSION_FLAG != 0 {
+		return ^uint64(0)
+	}
+
+	var everyone, memberOverwrite *PermissionOverwrite
+	var roleAllow, roleDeny uint64
+	for i := range overwrites {
+		overwrite := &overwrites[i]
+		switch {
+		case overwrite.Type == ROLE_PERMISSION_OVERWRITE_TYPE && overwrite.ID == guildID:
+			everyone = overwrite
+		case overwrite.Type == ROLE_PERMISSION_OVERWRITE_TYPE && member.HasRole(overwrite.ID):
+			roleAllow |= overwrite.Allow
+			roleDeny |= overwrite.Deny
+		case overwrite.Type == MEMBER_PERMISSION_OVERWRITE_TYPE && overwrite.ID == userID:
+			memberOverwrite = overwrite
+		}
+	}
+
+	if everyone != nil {
+		permissions = permissions&^everyone.Deny | everyone.Allow
+	}
+
+	permissions = permissions&^roleDeny | roleAllow
+	if memberOverwrite != nil {
+		permissions = permissions&^memberOverwrite.Deny | memberOverwrite.Allow
+	}
+
+	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
+		permissions &= VIEW_CHANNEL_PERMISSION_FLAG | READ_MESSAGE_HISTORY_PERMISSION_FLAG
+	}
+
+	return permissions
+}
+
+func isThreadChannel(channelType ChannelType) bool {
+	return channelType == GUILD_ANNOUNCEMENT_THREAD_CHANNEL_TYPE || channelType == GUILD_PUBLIC_THREAD_CHANNEL_TYPE || channelType == GUILD_PRIVATE_THREAD_CHANNEL_TYPE
+}
@@ -0,0 +1,48 @@
+package tempest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputePermissions(t *testing.T) {
+	const guildID, modRoleID, userID, ownerID Snowflake = 1, 2, 3, 5
+	roles := []Role{
+		{ID: guildID, PermissionFlags: VIEW_CHANNEL_PERMISSION_FLAG | SEND_MESSAGES_PERMISSION_FLAG},
+		{ID: modRoleID, PermissionFlags: MANAGE_MESSAGES_PERMISSION_FLAG},
+		{ID: 4, PermissionFlags: ADMINISTRATOR_PERMISSION_FLAG},
+	}
+	member := Member{User: &User{ID: userID}, RoleIDs: []Snowflake{modRoleID}}
+
+	guildLevel := ComputePermissions(guildID, ownerID, member, roles, nil)
+	if guildLevel != VIEW_CHANNEL_PERMISSION_FLAG|SEND_MESSAGES_PERMISSION_FLAG|MANAGE_MESSAGES_PERMISSION_FLAG {
+		t.Errorf("unexpected guild level permissions: %b", guildLevel)
+	}
+
+	overwrites := []PermissionOverwrite{
+		{ID: guildID, Type: ROLE_PERMISSION_OVERWRITE_TYPE, Deny: SEND_MESSAGES_PERMISSION_FLAG | VIEW_CHANNEL_PERMISSION_FLAG},
+		{ID: modRoleID, Type: ROLE_PERMISSION_OVERWRITE_TYPE, Allow: VIEW_CHANNEL_PERMISSION_FLAG},
+		{ID: userID, Type: MEMBER_PERMISSION_OVERWRITE_TYPE, Allow: SEND_MESSAGES_PERMISSION_FLAG, Deny: MANAGE_MESSAGES_PERMISSION_FLAG},
+	}
+
+	channelLevel := ComputePermissions(guildID, ownerID, member, roles, overwrites)
+	if channelLevel != VIEW_CHANNEL_PERMISSION_FLAG|SEND_MESSAGES_PERMISSION_FLAG {
+		t.Errorf("unexpected channel level permissions: %b", channelLevel)
+	}
+
+	admin := Member{User: &User{ID: userID}, RoleIDs: []Snowflake{4}}
+	if ComputePermissions(guildID, ownerID, admin, roles, overwrites)&SEND_MESSAGES_PERMISSION_FLAG == 0 {
+		t.Error("expected administrator to bypass channel overwrites")
+	}
+
+	owner := Member{User: &User{ID: ownerID}}
+	if ComputePermissions(guildID, ownerID, owner, roles, overwrites) != ^uint64(0) {
+		t.Error("expected guild owner to have all permissions")
+	}
+
+	timeout := time.Now().Add(time.Hour)
+	member.CommunicationDisabledUntil = &timeout
+	if ComputePermissions(guildID, ownerID, member, roles, overwrites) != VIEW_CHANNEL_PERMISSION_FLAG {
+		t.Error("expected member in timeout to keep only read permissions")
+	}
+}
@@ -0,0 +1,48 @@
+package tempest
+
+import "time"
+
+// https://discord.com/developers/docs/resources/poll#layout-type
+type PollLayoutType uint8
+
+const (
+	DEFAULT_POLL_LAYOUT_TYPE PollLayoutType = 1
+)
+
+// https://discord.com/developers/docs/resources/poll#poll-object-poll-object-structure
+//
+// When creating poll, use Duration instead of Expiry (Discord calculates expiry on its own).
+type Poll struct {
+	Question         PollMedia      `json:"question"` // Question can hold only text (up to 300 characters).
+	Answers          []PollAnswer   `json:"answers"`  // Up to 10 answers.
+	Expiry           *time.Time     `json:"expiry,omitempty"`
+	Duration         uint32         `json:"duration,omitempty"` // Number of hours the poll should be open for (up to 32 days). Only used when creating poll. (default: 24)
+	AllowMultiselect bool           `json:"allow_multiselect"`
+	LayoutType       PollLayoutType `json:"layout_type,omitempty"`
+	Results          *PollResults   `json:"results,omitempty"` // Only present on fetched polls. Discord doesn't guarantee its accuracy while poll is in progress.
+}
+
+// https://discord.com/developers/docs/resources/poll#poll-media-object-poll-media-object-structure
+type PollMedia struct {
+	Text  string        `json:"text,omitempty"` // Up to 55 characters for answers.
+	Emoji *PartialEmoji `json:"emoji,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/poll#poll-answer-object-poll-answer-object-structure
+type PollAnswer struct {
+	AnswerID  uint32    `json:"answer_id,omitempty"` // Set by Discord, it's only present on fetched polls.
+	PollMedia PollMedia `json:"poll_media"`
+}
+
+// https://discord.com/developers/docs/resources/poll#poll-results-object-poll-results-object-structure
+type PollResults struct {
+	IsFinalized  bool              `json:"is_finalized"` // Whether the votes have been precisely counted.
+	AnswerCounts []PollAnswerCount `json:"answer_counts"`
+}
+
+// https://discord.com/developers/docs/resources/poll#poll-results-object-poll-answer-count-object-structure
+type PollAnswerCount struct {
+	ID      uint32 `json:"id"`
+	Count   uint32 `json:"count"`
+	MeVoted bool   `json:"me_voted"` // Whether the current user voted for this answer.
+}
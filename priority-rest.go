@@ -0,0 +1,121 @@
+package tempest
+
+import "sync"
+
+// Wrapper over Rest that queues requests into two lanes. Its workers always pick high priority requests first,
+// so time-sensitive calls (like interaction responses that must reach Discord within 3s) don't wait behind background jobs.
+// Create it with NewPriorityRest function.
+//
+//	priority := tempest.NewPriorityRest(client.Rest, 0)
+//	defer priority.Close()
+//
+//	priority.RequestHigh(http.MethodPost, "/interactions/"+itx.ID.String()+"/"+itx.Token+"/callback", response)
+//	priority.RequestLow(http.MethodGet, "/guilds/"+guildID.String()+"/audit-logs", nil)
+type PriorityRest struct {
+	Rest *Rest
+
+	high      chan *priorityRequest
+	low       chan *priorityRequest
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type priorityRequest struct {
+	method      string
+	route       string
+	jsonPayload interface{}
+	result      chan priorityResult
+}
+
+type priorityResult struct {
+	raw  []byte
+	info *RateLimitInfo
+	err  error
+}
+
+// Creates new PriorityRest and launches its workers. Use 0 workers to match Rest's concurrency. (default: 10)
+func NewPriorityRest(rest *Rest, workers uint) *PriorityRest {
+	if workers == 0 {
+		workers = rest.Concurrency
+		if workers == 0 {
+			workers = DEFAULT_REST_CONCURRENCY
+		}
+	}
+
+	priority := &PriorityRest{
+		Rest: rest,
+		high: make(chan *priorityRequest),
+		low:  make(chan *priorityRequest),
+		done: make(chan struct{}),
+	}
+
+	for i := uint(0); i < workers; i++ {
+		go priority.work()
+	}
+
+	return priority
+}
+
+// Works like Rest.Request but request is handled before any waiting low priority request.
+func (priority *PriorityRest) RequestHigh(method string, route string, jsonPayload interface{}) ([]byte, *RateLimitInfo, error) {
+	return priority.enqueue(priority.high, method, route, jsonPayload)
+}
+
+// Works like Rest.Request but request is handled only when there's no high priority request waiting.
+func (priority *PriorityRest) RequestLow(method string, route string, jsonPayload interface{}) ([]byte, *RateLimitInfo, error) {
+	return priority.enqueue(priority.low, method, route, jsonPayload)
+}
+
+// Stops all workers. Requests that are already in progress will finish, new ones fail with ErrPriorityRestClosed.
+// It's safe to call it multiple times.
+func (priority *PriorityRest) Close() {
+	priority.closeOnce.Do(func() {
+		close(priority.done)
+	})
+}
+
+func (priority *PriorityRest) enqueue(lane chan *priorityRequest, method string, route string, jsonPayload interface{}) ([]byte, *RateLimitInfo, error) {
+	req := &priorityRequest{
+		method:      method,
+		route:       route,
+		jsonPayload: jsonPayload,
+		result:      make(chan priorityResult, 1),
+	}
+
+	select {
+	case lane <- req:
+	case <-priority.done:
+		return nil, nil, ErrPriorityRestClosed
+	}
+
+	res := <-req.result
+	return res.raw, res.info, res.err
+}
+
+func (priority *PriorityRest) work() {
+	for {
+		// Drain high priority lane first, fall back to waiting on both lanes only when it's empty.
+		select {
+		case req := <-priority.high:
+			priority.handle(req)
+			continue
+		case <-priority.done:
+			return
+		default:
+		}
+
+		select {
+		case req := <-priority.high:
+			priority.handle(req)
+		case req := <-priority.low:
+			priority.handle(req)
+		case <-priority.done:
+			return
+		}
+	}
+}
+
+func (priority *PriorityRest) handle(req *priorityRequest) {
+	raw, info, err := priority.Rest.Request(req.method, req.route, req.jsonPayload)
+	req.result <- priorityResult{raw: raw, info: info, err: err}
+}
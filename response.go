@@ -15,6 +15,30 @@ const (
 	MODAL_RESPONSE_TYPE // Not available for MODAL_SUBMIT and PING interactions.
 )
 
+func (rt ResponseType) String() string {
+	switch rt {
+	case PONG_RESPONSE_TYPE:
+		return "PONG"
+	case ACKNOWLEDGE_RESPONSE_TYPE:
+		return "ACKNOWLEDGE"
+	case CHANNEL_MESSAGE_RESPONSE_TYPE:
+		return "CHANNEL_MESSAGE"
+	case CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE:
+		return "CHANNEL_MESSAGE_WITH_SOURCE"
+	case DEFERRED_CHANNEL_MESSAGE_WITH_SOURCE_RESPONSE_TYPE:
+		return "DEFERRED_CHANNEL_MESSAGE_WITH_SOURCE"
+	case DEFERRED_UPDATE_MESSAGE_RESPONSE_TYPE:
+		return "DEFERRED_UPDATE_MESSAGE"
+	case UPDATE_MESSAGE_RESPONSE_TYPE:
+		return "UPDATE_MESSAGE"
+	case AUTOCOMPLETE_RESPONSE_TYPE:
+		return "APPLICATION_COMMAND_AUTOCOMPLETE_RESULT"
+	case MODAL_RESPONSE_TYPE:
+		return "MODAL"
+	}
+	return "UNKNOWN"
+}
+
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object
 type ResponseMessage struct {
 	Type ResponseType         `json:"type"`
@@ -27,7 +51,7 @@ type ResponseMessageData struct {
 	Content         string           `json:"content,omitempty"`
 	Embeds          []*Embed         `json:"embeds,omitempty"`
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
-	Flags           uint64           `json:"flags,omitempty"`
+	Flags           uint64           `json:"flags,omitempty"` // Bit set of message flags, only EPHEMERAL_MESSAGE_FLAG, SUPPRESS_EMBEDS_MESSAGE_FLAG and SUPPRESS_NOTIFICATIONS_MESSAGE_FLAG can be set.
 	Components      []*ComponentRow  `json:"components,omitempty"`
 }
 
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,122 +16,408 @@ import (
 )
 
 type Rest struct {
-	mu         sync.RWMutex
-	token      string
-	httpClient *http.Client
-	lockedTo   time.Time
+	RateLimitBuffer time.Duration // Extra time added on top of Discord's retry_after to account for clock skew. (default: 100ms)
+	Concurrency     uint          // Max number of requests that can be in-flight at the same time. Changing it after first request has no effect. (default: 10)
+	MaxResponseSize int64         // Max size (in bytes) of response body, larger responses fail with ErrResponseTooLarge. (default: 10 MiB)
+
+	// Version of Discord API (like "9" or "10") requests are sent to. Ignored when BaseURL is set. (default: DISCORD_API_VERSION)
+	APIVersion string
+
+	// Url that all routes are relative to. Change it to route requests through API proxy or into mock server.
+	// When left empty, Discord API url for APIVersion is used. (default: <empty>)
+	BaseURL string
+
+	// Response status codes that are treated like connection errors, meaning request will be retried (up to REST_MAX_ATTEMPTS attempts in total).
+	// Rest waits for response's Retry-After (when sent) or REST_RETRY_DELAY multiplied by number of failed attempts. (default: 502, 503, 504)
+	RetryableStatusCodes []int
+
+	// Optional cache for ETag headers. When set, GET requests authorized with app token are sent with If-None-Match header
+	// and 304 Not Modified responses return previously cached body. (default: <nil>)
+	ETagger ETagger
+
+	// Whether identical GET requests (authorized with app token) that run at the same time should be merged into single call to Discord.
+	// All callers receive the same response (and share returned byte slice, so don't modify it). (default: false)
+	EnableSingleFlight bool
+
+	flightsMu     sync.Mutex
+	flights       map[string]*restFlight
+	semaphore     chan struct{}
+	semaphoreOnce sync.Once
+	mu            sync.RWMutex
+	token         string
+	httpClient    *http.Client
+	lockedTo      time.Time
 }
 
 type rateLimitError struct {
 	Global     bool    `json:"global"`
 	Message    string  `json:"message"`
-	RetryAfter float32 `json:"retry_after"`
+	RetryAfter float64 `json:"retry_after"` // In seconds.
+}
+
+// Rate limit state of route's bucket as reported by Discord with last response.
+// It's <nil> when response didn't include rate limit headers (or no response was received at all).
+type RateLimitInfo struct {
+	Remaining int       // Number of requests that can still be made before hitting limit.
+	Limit     int       // Number of requests that can be made in total within current window.
+	Reset     time.Time // Moment when limit resets.
+	Bucket    string    // Unique string denoting rate limit being encountered.
 }
 
-func (rest *Rest) Request(method string, route string, jsonPayload interface{}) ([]byte, error) {
+// Makes request to Discord API. Returned rate limit info (when available) lets callers back off preemptively before library forces a sleep.
+//
+//	_, _, err := rest.Request(http.MethodDelete, "/channels/"+channelID.String(), nil)
+func (rest *Rest) Request(method string, route string, jsonPayload interface{}) ([]byte, *RateLimitInfo, error) {
+	return rest.RequestWithFiles(method, route, jsonPayload, nil)
+}
+
+// Works like Request but sends payload as multipart/form-data together with provided files (payload goes into "payload_json" field).
+// Attached files can be later referenced in embeds with "attachment://<file name>" urls.
+func (rest *Rest) RequestWithFiles(method string, route string, jsonPayload interface{}, files []File) ([]byte, *RateLimitInfo, error) {
+	return rest.request(method, route, rest.token, jsonPayload, files)
+}
+
+// Works like Request but authorizes it with OAuth2 bearer token (access token of user that authorized your app) instead of app token.
+// Use it for routes like "/users/@me" that return data about authorized user.
+func (rest *Rest) RequestWithBearer(bearerToken string, method string, route string, jsonPayload interface{}) ([]byte, *RateLimitInfo, error) {
+	return rest.request(method, route, "Bearer "+strings.TrimPrefix(bearerToken, "Bearer "), jsonPayload, nil)
+}
+
+// In-flight request shared by concurrent callers of Rest with EnableSingleFlight.
+type restFlight struct {
+	done chan struct{}
+	raw  []byte
+	info *RateLimitInfo
+	err  error
+}
+
+func (rest *Rest) request(method string, route string, authorization string, jsonPayload interface{}, files []File) ([]byte, *RateLimitInfo, error) {
+	if !rest.EnableSingleFlight || method != http.MethodGet || authorization != rest.token {
+		return rest.doRequest(method, route, authorization, jsonPayload, files)
+	}
+
+	key := method + " " + route
+	rest.flightsMu.Lock()
+	if flight, available := rest.flights[key]; available {
+		rest.flightsMu.Unlock()
+		<-flight.done
+		return flight.raw, flight.info, flight.err
+	}
+
+	if rest.flights == nil {
+		rest.flights = make(map[string]*restFlight)
+	}
+
+	flight := &restFlight{done: make(chan struct{})}
+	rest.flights[key] = flight
+	rest.flightsMu.Unlock()
+
+	defer func() {
+		rest.flightsMu.Lock()
+		delete(rest.flights, key)
+		rest.flightsMu.Unlock()
+		close(flight.done)
+	}()
+
+	flight.raw, flight.info, flight.err = rest.doRequest(method, route, authorization, jsonPayload, files)
+	return flight.raw, flight.info, flight.err
+}
+
+func (rest *Rest) doRequest(method string, route string, authorization string, jsonPayload interface{}, files []File) ([]byte, *RateLimitInfo, error) {
 	rest.mu.RLock()
-	if !rest.lockedTo.IsZero() {
-		timeLeft := time.Until(rest.lockedTo)
-		rest.mu.RUnlock()
-		if timeLeft > 0 {
+	lockedTo := rest.lockedTo
+	rest.mu.RUnlock()
+
+	if !lockedTo.IsZero() {
+		if timeLeft := time.Until(lockedTo); timeLeft > 0 {
 			time.Sleep(timeLeft)
 		}
 	}
 
-	for i := 1; i < 3; i++ {
-		raw, err, finished := rest.handleRequest(method, route, jsonPayload)
-		if finished {
-			return raw, err
+	rest.semaphoreOnce.Do(func() {
+		limit := rest.Concurrency
+		if limit == 0 {
+			limit = DEFAULT_REST_CONCURRENCY
 		}
-		time.Sleep(time.Microsecond * time.Duration(250*i))
-	}
+		rest.semaphore = make(chan struct{}, limit)
+	})
 
-	return nil, errors.New("failed to make http request 3 times to " + method + " :: " + route + " (check internet connection and/or app credentials)")
-}
+	for attempt := 1; ; attempt++ {
+		rest.semaphore <- struct{}{}
+		raw, info, err, retryAfter, finished := rest.handleRequest(method, route, authorization, jsonPayload, files)
+		<-rest.semaphore
 
-func (rest *Rest) handleRequest(method string, route string, jsonPayload interface{}) ([]byte, error, bool) {
-	var req *http.Request
-	if jsonPayload == nil {
-		request, err := http.NewRequest(method, DISCORD_API_URL+route, nil)
-		if err != nil {
-			return nil, errors.New("failed to initialize new request: " + err.Error()), false
+		if finished {
+			return raw, info, err
 		}
-		req = request
-	} else {
-		body, err := sonnet.Marshal(jsonPayload)
-		if err != nil {
-			return nil, errors.New("failed to parse provided payload (make sure it's in JSON format)"), true
+
+		if attempt == REST_MAX_ATTEMPTS {
+			return nil, info, err
 		}
 
-		request, err := http.NewRequest(
-			method,
-			DISCORD_API_URL+route,
-			bytes.NewBuffer(
-				bytes.ReplaceAll(
-					body,
-					private_REST_NULL_SLICE_FIND,
-					private_REST_NULL_SLICE_REPLACE,
-				),
-			),
-		)
+		if retryAfter == 0 {
+			retryAfter = REST_RETRY_DELAY * time.Duration(attempt)
+		}
+		time.Sleep(retryAfter)
+	}
+}
 
-		if err != nil {
-			return nil, errors.New("failed to initialize new request: " + err.Error()), false
+// Makes single attempt. When it's not finished (request can be retried), returned duration tells how long to wait before next attempt (0 = use back-off).
+func (rest *Rest) handleRequest(method string, route string, authorization string, jsonPayload interface{}, files []File) ([]byte, *RateLimitInfo, error, time.Duration, bool) {
+	var reqBody io.Reader
+	contentType := "application/json"
+
+	if jsonPayload != nil || len(files) != 0 {
+		var payload []byte
+		if jsonPayload != nil {
+			encoded, err := sonnet.Marshal(jsonPayload)
+			if err != nil {
+				return nil, nil, errors.New("failed to parse provided payload (make sure it's in JSON format)"), 0, true
+			}
+			payload = bytes.ReplaceAll(encoded, private_REST_NULL_SLICE_FIND, private_REST_NULL_SLICE_REPLACE)
+		}
+
+		if len(files) == 0 {
+			reqBody = bytes.NewBuffer(payload)
+		} else {
+			buf, boundaryContentType, err := multipartBody(payload, files)
+			if err != nil {
+				return nil, nil, errors.New("failed to prepare multipart body: " + err.Error()), 0, true
+			}
+			reqBody, contentType = buf, boundaryContentType
 		}
-		req = request
 	}
 
-	req.Header.Add("Content-Type", "application/json")
+	req, err := http.NewRequest(method, rest.baseURL()+route, reqBody)
+	if err != nil {
+		return nil, nil, errors.New("failed to initialize new request: " + err.Error()), 0, false
+	}
+
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("User-Agent", USER_AGENT)
-	req.Header.Add("Authorization", rest.token)
+	req.Header.Add("Authorization", authorization)
+
+	// Bodies fetched with bearer tokens belong to specific users so they're never cached.
+	tagger := rest.ETagger
+	if method != http.MethodGet || authorization != rest.token {
+		tagger = nil
+	}
+
+	var cachedBody []byte
+	if tagger != nil {
+		var etag string
+		if etag, cachedBody = tagger.Get(route); etag != "" {
+			req.Header.Add("If-None-Match", etag)
+		}
+	}
 
 	res, err := rest.httpClient.Do(req)
 	if err != nil {
-		return nil, errors.New("failed to process request: " + err.Error()), false
+		return nil, nil, errors.New("failed to process request: " + err.Error()), 0, false
 	}
+	defer res.Body.Close()
 
+	info := parseRateLimitInfo(res.Header)
 	if res.StatusCode == 204 {
-		return nil, nil, true
+		return nil, info, nil, 0, true
 	}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, errors.New("failed to parse response body (json): " + err.Error()), true
+	if res.StatusCode == 304 && tagger != nil {
+		return cachedBody, info, nil, 0, true
 	}
 
-	if res.StatusCode == 429 {
-		rateErr := rateLimitError{}
-		sonnet.Unmarshal(body, &rateErr)
+	maxSize := rest.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = DEFAULT_MAX_RESPONSE_SIZE
+	}
 
-		rest.mu.Lock()
-		timeLeft := time.Now().Add(time.Second * time.Duration(rateErr.RetryAfter+5))
-		rest.lockedTo = timeLeft
-		rest.mu.Unlock()
+	// Read one extra byte to tell apart body that exactly fits the limit from the one exceeding it.
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, info, errors.New("failed to parse response body (json): " + err.Error()), 0, true
+	}
 
-		time.Sleep(time.Until(timeLeft))
+	if int64(len(body)) > maxSize {
+		return nil, info, ErrResponseTooLarge, 0, true
+	}
 
+	if res.StatusCode == 429 {
+		// Caller sleeps after releasing its concurrency slot, so requests to other buckets don't wait on it.
+		wait := parseRetryAfter(res.Header, body) + rest.RateLimitBuffer
 		rest.mu.Lock()
-		rest.lockedTo = time.Time{}
+		if lockedTo := time.Now().Add(wait); lockedTo.After(rest.lockedTo) {
+			rest.lockedTo = lockedTo
+		}
 		rest.mu.Unlock()
-		return nil, errors.New("rate limit"), false
+		return nil, info, errors.New(res.Status + " :: " + string(body)), wait, false
+	} else if rest.isRetryable(res.StatusCode) {
+		return nil, info, errors.New(res.Status + " :: " + string(body)), parseRetryAfter(res.Header, body), false
 	} else if res.StatusCode >= 400 {
-		return nil, errors.New(res.Status + " :: " + string(body)), true
+		return nil, info, errors.New(res.Status + " :: " + string(body)), 0, true
+	}
+
+	if tagger != nil {
+		if etag := res.Header.Get("ETag"); etag != "" {
+			tagger.Set(route, etag, body)
+		}
+	}
+
+	return body, info, nil, 0, true
+}
+
+func (rest *Rest) isRetryable(statusCode int) bool {
+	for _, code := range rest.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Encodes JSON payload and files into multipart/form-data body. Returns body together with matching content type (with boundary).
+func multipartBody(payload []byte, files []File) (*bytes.Buffer, string, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+
+	if payload != nil {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="payload_json"`)
+		header.Set("Content-Type", "application/json")
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err = part.Write(payload); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i, file := range files {
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="files[`+strconv.Itoa(i)+`]"; filename="`+multipartQuoteEscaper.Replace(file.Name)+`"`)
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err = part.Write(file.Data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
 	}
 
-	return body, nil, true
+	return buf, writer.FormDataContentType(), nil
 }
 
+var multipartQuoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// Reads how long to wait before retrying rate limited request.
+// Discord sends it as float seconds in both body (retry_after) and Retry-After header so header is used as fallback.
+func parseRetryAfter(header http.Header, body []byte) time.Duration {
+	rateErr := rateLimitError{}
+	if err := sonnet.Unmarshal(body, &rateErr); err == nil && rateErr.RetryAfter > 0 {
+		return time.Duration(rateErr.RetryAfter * float64(time.Second))
+	}
+
+	seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64)
+	if err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	return 0
+}
+
+// Reads X-RateLimit-* headers, returns <nil> when response doesn't carry them.
+func parseRateLimitInfo(header http.Header) *RateLimitInfo {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+
+	info := &RateLimitInfo{
+		Limit:  limit,
+		Bucket: header.Get("X-RateLimit-Bucket"),
+	}
+
+	info.Remaining, _ = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset"), 64); err == nil {
+		info.Reset = time.UnixMilli(int64(reset * 1000))
+	}
+
+	return info
+}
+
+// Returns url that request routes are appended to.
+func (rest *Rest) baseURL() string {
+	if rest.BaseURL != "" {
+		return strings.TrimSuffix(rest.BaseURL, "/")
+	}
+
+	if rest.APIVersion == "" {
+		return DISCORD_API_URL
+	}
+
+	return DISCORD_API_ROOT_URL + "/v" + rest.APIVersion
+}
+
+// Creates new Rest with default, 30s timeout per request.
 func NewRest(token string) *Rest {
-	return NewCustomRest(token, http.DefaultClient)
+	return NewRestWithTimeout(token, DEFAULT_REST_TIMEOUT)
+}
+
+// Creates new Rest whose requests are cancelled after set timeout. Use NewCustomRest if you need more control over http client.
+func NewRestWithTimeout(token string, timeout time.Duration) *Rest {
+	return NewCustomRest(token, &http.Client{
+		Timeout:   timeout,
+		Transport: newRestTransport(),
+	})
+}
+
+// Discord API supports HTTP/2 so parallel requests can be multiplexed over single connection.
+// Protocol is negotiated through ALPN during TLS handshake, it falls back to HTTP/1.1 when not available.
+func newRestTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = DEFAULT_REST_CONCURRENCY
+	return transport
+}
+
+// Creates new Rest with default timeout that sends requests to selected Discord API version (with or without "v" prefix, like "9" or "v9").
+func NewRestWithVersion(token string, version string) *Rest {
+	rest := NewRest(token)
+	rest.APIVersion = strings.TrimPrefix(version, "v")
+	return rest
 }
 
+// Creates new Rest with provided http client. Set Rest.BaseURL afterwards if requests should go anywhere else than Discord API.
 func NewCustomRest(token string, client *http.Client) *Rest {
 	if !strings.HasPrefix(token, "Bot ") {
 		panic("app token needs to start with \"Bot \" prefix (example: \"Bot XYZABCQEWQ\")")
 	}
 
 	return &Rest{
-		token:      token,
-		httpClient: client,
+		RateLimitBuffer: DEFAULT_RATE_LIMIT_BUFFER,
+		Concurrency:     DEFAULT_REST_CONCURRENCY,
+		MaxResponseSize: DEFAULT_MAX_RESPONSE_SIZE,
+		APIVersion:      DISCORD_API_VERSION,
+		token:           token,
+		httpClient:      client,
+		RetryableStatusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
 	}
 }
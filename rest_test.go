@@ -1,10 +1,18 @@
 package tempest
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // Spams any request to check for Rest race conditions.
@@ -19,9 +27,362 @@ func TestRest(t *testing.T) {
 }
 
 func requestGateway(rest *Rest, t *testing.T) {
-	body, err := rest.Request(http.MethodGet, "/gateway/bot", nil)
+	body, _, err := rest.Request(http.MethodGet, "/gateway/bot", nil)
 	if err != nil {
 		t.Error(err)
 	}
 	fmt.Println(string(body))
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	header := http.Header{}
+
+	if d := parseRetryAfter(header, []byte(`{"message": "You are being rate limited.", "retry_after": 1.5, "global": false}`)); d != time.Millisecond*1500 {
+		t.Errorf("expected 1.5s from response body, got: %s", d)
+	}
+
+	header.Set("Retry-After", "2.25")
+	if d := parseRetryAfter(header, nil); d != time.Millisecond*2250 {
+		t.Errorf("expected 2.25s from Retry-After header, got: %s", d)
+	}
+
+	if d := parseRetryAfter(http.Header{}, nil); d != 0 {
+		t.Errorf("expected no wait time without rate limit info, got: %s", d)
+	}
+}
+
+func TestParseRateLimitInfo(t *testing.T) {
+	if info := parseRateLimitInfo(http.Header{}); info != nil {
+		t.Errorf("expected no rate limit info without headers, got: %+v", info)
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "5")
+	header.Set("X-RateLimit-Remaining", "1")
+	header.Set("X-RateLimit-Reset", "1470173023.123")
+	header.Set("X-RateLimit-Bucket", "abcd1234")
+
+	info := parseRateLimitInfo(header)
+	if info == nil {
+		t.Fatal("expected rate limit info")
+	}
+
+	if info.Limit != 5 || info.Remaining != 1 || info.Bucket != "abcd1234" {
+		t.Errorf("unexpected rate limit info: %+v", info)
+	}
+
+	if !info.Reset.Equal(time.UnixMilli(1470173023123)) {
+		t.Errorf("unexpected reset time: %s", info.Reset)
+	}
+}
+
+type staticTransport struct {
+	body string
+}
+
+func (t staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestRestMaxResponseSize(t *testing.T) {
+	rest := NewCustomRest("Bot test", &http.Client{Transport: staticTransport{body: `{"url": "wss://gateway.discord.gg"}`}})
+	rest.MaxResponseSize = 35
+
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); err != nil {
+		t.Errorf("expected body that fits limit to be read, got: %s", err)
+	}
+
+	rest.MaxResponseSize = 34
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got: %v", err)
+	}
+}
+
+type flakyTransport struct {
+	failures   *int
+	retryAfter string
+}
+
+func (t flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	statusCode, header, body := http.StatusOK, http.Header{}, `{}`
+	if *t.failures > 0 {
+		*t.failures--
+		statusCode, body = http.StatusBadGateway, `upstream unavailable`
+		if t.retryAfter != "" {
+			header.Set("Retry-After", t.retryAfter)
+		}
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestRestRetryableStatusCodes(t *testing.T) {
+	failures := REST_MAX_ATTEMPTS - 1
+	rest := NewCustomRest("Bot test", &http.Client{Transport: flakyTransport{failures: &failures, retryAfter: "0.001"}})
+
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); err != nil || failures != 0 {
+		t.Errorf("expected request to succeed on last attempt after retrying 502 responses, got: %v", err)
+	}
+
+	failures = REST_MAX_ATTEMPTS
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); err == nil || err.Error() != "Bad Gateway :: upstream unavailable" {
+		t.Errorf("expected last attempt's error after running out of attempts, got: %v", err)
+	}
+
+	// Without Retry-After header, Rest falls back to its own back-off.
+	failures = 1
+	rest = NewCustomRest("Bot test", &http.Client{Transport: flakyTransport{failures: &failures}})
+	start := time.Now()
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < REST_RETRY_DELAY {
+		t.Errorf("expected retry to wait for back-off, took: %s", elapsed)
+	}
+
+	failures = 1
+	rest.RetryableStatusCodes = nil
+	if _, _, err := rest.Request(http.MethodGet, "/gateway", nil); err == nil {
+		t.Error("expected 502 response to fail without retry when it's not listed as retryable")
+	}
+}
+
+type rateLimitedTransport struct {
+	limited chan struct{}
+}
+
+func (t rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.limited <- struct{}{}:
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Status:     http.StatusText(http.StatusTooManyRequests),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "You are being rate limited.", "retry_after": 0.2, "global": false}`)),
+			Request:    req,
+		}, nil
+	default:
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`)), Request: req}, nil
+}
+
+func TestRestReleasesSlotWhileRateLimited(t *testing.T) {
+	limited := make(chan struct{})
+	rest := NewCustomRest("Bot test", &http.Client{Transport: rateLimitedTransport{limited: limited}})
+	rest.Concurrency = 1
+
+	finished := make(chan error)
+	go func() {
+		_, _, err := rest.Request(http.MethodGet, "/gateway", nil)
+		finished <- err
+	}()
+	<-limited
+
+	released := false
+	for deadline := time.Now().Add(time.Millisecond * 150); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if len(rest.semaphore) == 0 {
+			released = true
+			break
+		}
+	}
+
+	if !released {
+		t.Error("expected concurrency slot to be released while waiting for rate limit")
+	}
+
+	if err := <-finished; err != nil {
+		t.Errorf("expected request to succeed after rate limit, got: %s", err)
+	}
+}
+
+func TestRestAPIVersion(t *testing.T) {
+	rest := NewRest("Bot test")
+	if url := rest.baseURL(); url != DISCORD_API_URL {
+		t.Errorf("expected default api url, got: %s", url)
+	}
+
+	if url := NewRestWithVersion("Bot test", "v9").baseURL(); url != "https://discord.com/api/v9" {
+		t.Errorf("expected url of selected api version, got: %s", url)
+	}
+
+	rest.BaseURL = "https://proxy.example/api/"
+	if url := rest.baseURL(); url != "https://proxy.example/api" {
+		t.Errorf("expected base url to take precedence over api version, got: %s", url)
+	}
+}
+
+type etagTransport struct {
+	requests *int
+}
+
+func (t etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*t.requests++
+	if req.Header.Get("If-None-Match") == `"v1"` {
+		return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{`"v1"`}},
+		Body:       io.NopCloser(strings.NewReader(`{"id":"1"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestRestETagger(t *testing.T) {
+	var requests int
+	rest := NewCustomRest("Bot test", &http.Client{Transport: etagTransport{requests: &requests}})
+	rest.ETagger = NewMemoryETagger()
+
+	for i := 0; i < 2; i++ {
+		raw, _, err := rest.Request(http.MethodGet, "/users/1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(raw) != `{"id":"1"}` {
+			t.Errorf("expected (cached) body on request %d, got: %q", i+1, raw)
+		}
+	}
+
+	if etag, _ := rest.ETagger.Get("/users/1"); requests != 2 || etag != `"v1"` {
+		t.Errorf("unexpected etag state: %d requests, etag %q", requests, etag)
+	}
+
+	if _, _, err := rest.RequestWithBearer("user-token", http.MethodGet, "/users/@me", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if etag, _ := rest.ETagger.Get("/users/@me"); etag != "" {
+		t.Error("expected bearer requests to skip etag cache")
+	}
+}
+
+type blockingTransport struct {
+	requests *int32
+	release  chan struct{}
+}
+
+func (t blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(t.requests, 1)
+	<-t.release
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"id":"1"}`)), Request: req}, nil
+}
+
+func TestRestSingleFlight(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	rest := NewCustomRest("Bot test", &http.Client{Transport: blockingTransport{requests: &requests, release: release}})
+	rest.EnableSingleFlight = true
+
+	var wg sync.WaitGroup
+	results := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			raw, _, err := rest.Request(http.MethodGet, "/users/1", nil)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- string(raw)
+		}()
+	}
+
+	// Give all callers time to join the in-flight request before releasing it.
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for result := range results {
+		if result != `{"id":"1"}` {
+			t.Errorf("unexpected shared response: %q", result)
+		}
+	}
+
+	if count := atomic.LoadInt32(&requests); count != 1 {
+		t.Errorf("expected single request to reach discord, got: %d", count)
+	}
+
+	if _, _, err := rest.Request(http.MethodGet, "/users/1", nil); err != nil || atomic.LoadInt32(&requests) != 2 {
+		t.Error("expected finished request to no longer be shared")
+	}
+}
+
+func TestPriorityRest(t *testing.T) {
+	rest := NewCustomRest("Bot test", &http.Client{Transport: staticTransport{body: `{}`}})
+	priority := NewPriorityRest(rest, 2)
+
+	if raw, _, err := priority.RequestHigh(http.MethodGet, "/gateway", nil); err != nil || string(raw) != `{}` {
+		t.Errorf("unexpected high priority result: %s %v", raw, err)
+	}
+
+	if raw, _, err := priority.RequestLow(http.MethodGet, "/gateway", nil); err != nil || string(raw) != `{}` {
+		t.Errorf("unexpected low priority result: %s %v", raw, err)
+	}
+
+	priority.Close()
+	priority.Close()
+
+	if _, _, err := priority.RequestHigh(http.MethodGet, "/gateway", nil); !errors.Is(err, ErrPriorityRestClosed) {
+		t.Errorf("expected ErrPriorityRestClosed, got: %v", err)
+	}
+}
+
+func TestRestTransport(t *testing.T) {
+	rest := NewRest("Bot test")
+
+	transport, ok := rest.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got: %T", rest.httpClient.Transport)
+	}
+
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("expected transport to attempt HTTP/2")
+	}
+
+	if rest.httpClient.Timeout != DEFAULT_REST_TIMEOUT {
+		t.Errorf("expected %s timeout, got: %s", DEFAULT_REST_TIMEOUT, rest.httpClient.Timeout)
+	}
+}
+
+func TestMultipartBody(t *testing.T) {
+	body, contentType, err := multipartBody([]byte(`{"name":"post"}`), []File{{Name: "notes.txt", ContentType: "text/plain", Data: []byte("hello")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := multipart.NewReader(body, params["boundary"])
+	form, err := reader.ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload := form.Value["payload_json"]; len(payload) != 1 || payload[0] != `{"name":"post"}` {
+		t.Errorf("unexpected payload_json field: %v", payload)
+	}
+
+	files := form.File["files[0]"]
+	if len(files) != 1 || files[0].Filename != "notes.txt" || files[0].Size != 5 {
+		t.Errorf("unexpected files[0] field: %+v", files)
+	}
+}
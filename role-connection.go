@@ -0,0 +1,32 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/application-role-connection-metadata#application-role-connection-metadata-object-application-role-connection-metadata-type
+type RoleConnectionMetadataType uint8
+
+const (
+	INTEGER_LESS_THAN_OR_EQUAL_ROLE_CONNECTION_METADATA_TYPE     RoleConnectionMetadataType = iota + 1 // Metadata value (integer) is less than or equal to guild's configured value.
+	INTEGER_GREATER_THAN_OR_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                        // Metadata value (integer) is greater than or equal to guild's configured value.
+	INTEGER_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                                        // Metadata value (integer) is equal to guild's configured value.
+	INTEGER_NOT_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                                    // Metadata value (integer) is not equal to guild's configured value.
+	DATETIME_LESS_THAN_OR_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                          // Metadata value (ISO8601 string) is less or equal to guild's configured value (days before current date).
+	DATETIME_GREATER_THAN_OR_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                       // Metadata value (ISO8601 string) is greater than guild's configured value (days before current date).
+	BOOLEAN_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                                        // Metadata value ("0" or "1") is equal to guild's configured value.
+	BOOLEAN_NOT_EQUAL_ROLE_CONNECTION_METADATA_TYPE                                                    // Metadata value ("0" or "1") is not equal to guild's configured value.
+)
+
+// https://discord.com/developers/docs/resources/application-role-connection-metadata#application-role-connection-metadata-object-application-role-connection-metadata-structure
+type RoleConnectionMetadata struct {
+	Type                     RoleConnectionMetadataType `json:"type"`
+	Key                      string                     `json:"key"`                                 // Dictionary key for the metadata field (a-z, 0-9, or _ characters; 1-50 characters).
+	Name                     string                     `json:"name"`                                // 1-100 characters.
+	NameLocalizations        map[string]string          `json:"name_localizations,omitempty"`        // https://discord.com/developers/docs/reference#locales
+	Description              string                     `json:"description"`                         // 1-200 characters.
+	DescriptionLocalizations map[string]string          `json:"description_localizations,omitempty"` // https://discord.com/developers/docs/reference#locales
+}
+
+// https://discord.com/developers/docs/resources/user#application-role-connection-object
+type roleConnectionParams struct {
+	PlatformName     string            `json:"platform_name,omitempty"`
+	PlatformUsername string            `json:"platform_username,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"` // Keys must match RoleConnectionMetadata.Key of registered records.
+}
@@ -0,0 +1,35 @@
+package tempest
+
+// https://discord.com/developers/docs/monetization/skus#sku-object-sku-types
+type SKUType uint8
+
+const (
+	DURABLE_SKU_TYPE            SKUType = 2 // Durable one-time purchase.
+	CONSUMABLE_SKU_TYPE         SKUType = 3 // Consumable one-time purchase.
+	SUBSCRIPTION_SKU_TYPE       SKUType = 5 // Represents a recurring subscription.
+	SUBSCRIPTION_GROUP_SKU_TYPE SKUType = 6 // System-generated group for each SUBSCRIPTION_SKU_TYPE sku created.
+)
+
+// https://discord.com/developers/docs/monetization/skus#sku-object-sku-flags
+type SKUFlag uint64
+
+const (
+	AVAILABLE_SKU_FLAG          SKUFlag = 1 << 2 // Sku is available for purchase.
+	GUILD_SUBSCRIPTION_SKU_FLAG SKUFlag = 1 << 7 // Recurring sku that can be purchased by a user and applied to a single server.
+	USER_SUBSCRIPTION_SKU_FLAG  SKUFlag = 1 << 8 // Recurring sku purchased by a user for themselves.
+)
+
+// https://discord.com/developers/docs/monetization/skus#sku-object-sku-structure
+type SKU struct {
+	ID            Snowflake `json:"id"`
+	Type          SKUType   `json:"type"`
+	ApplicationID Snowflake `json:"application_id"`
+	Name          string    `json:"name"` // Customer-facing name of your premium offering.
+	Slug          string    `json:"slug"` // System-generated url slug based on the sku's name.
+	Flags         SKUFlag   `json:"flags"`
+}
+
+// Checks whether sku has given flag set.
+func (sku SKU) HasFlag(flag SKUFlag) bool {
+	return sku.Flags&flag == flag
+}
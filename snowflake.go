@@ -3,13 +3,14 @@ package tempest
 import (
 	"strconv"
 	"time"
-
-	"github.com/sugawarayuuta/sonnet"
 )
 
 // Snowflake represents a Discord's id snowflake.
 type Snowflake uint64
 
+// Zero value snowflake, used across library (and Discord API) to mark id as not set.
+const ZERO_SNOWFLAKE Snowflake = 0
+
 func StringToSnowflake(s string) (Snowflake, error) {
 	i, err := strconv.ParseUint(s, 10, 64)
 	return Snowflake(i), err
@@ -19,19 +20,36 @@ func (s Snowflake) String() string {
 	return strconv.FormatUint(uint64(s), 10)
 }
 
+// Whether snowflake is not set (equals ZERO_SNOWFLAKE).
+func (s Snowflake) IsZero() bool {
+	return s == ZERO_SNOWFLAKE
+}
+
 func (s Snowflake) CreationTimestamp() time.Time {
 	return time.UnixMilli(int64(s>>22 + EPOCH))
 }
 
+// Snowflakes are always encoded as strings, same as Discord does, since they exceed max safe integer of JavaScript's numbers.
 func (s Snowflake) MarshalJSON() ([]byte, error) {
-	b := strconv.FormatUint(uint64(s), 10)
-	return sonnet.Marshal(b)
+	b := make([]byte, 0, 22)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(s), 10)
+	return append(b, '"'), nil
 }
 
+// Accepts both string and number encoded snowflakes. Null leaves snowflake unchanged.
 func (s *Snowflake) UnmarshalJSON(b []byte) error {
-	str, err := strconv.Unquote(string(b))
-	if err != nil {
-		return err
+	str := string(b)
+	if str == "null" {
+		return nil
+	}
+
+	if len(str) != 0 && str[0] == '"' {
+		var err error
+		str, err = strconv.Unquote(str)
+		if err != nil {
+			return err
+		}
 	}
 
 	i, err := strconv.ParseUint(str, 10, 64)
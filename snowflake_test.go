@@ -2,6 +2,8 @@ package tempest
 
 import (
 	"testing"
+
+	"github.com/sugawarayuuta/sonnet"
 )
 
 // Tried to encode & decode few example snowflakes
@@ -37,3 +39,43 @@ func TestSnowflake(t *testing.T) {
 		t.Errorf("failed to read creation timestamp from %s snowflake", s.String())
 	}
 }
+
+func TestSnowflakeIsZero(t *testing.T) {
+	if !ZERO_SNOWFLAKE.IsZero() {
+		t.Error("zero snowflake should report it's not set")
+	}
+
+	if Snowflake(327690719085068289).IsZero() {
+		t.Error("non zero snowflake should report it's set")
+	}
+}
+
+func TestSnowflakeJSON(t *testing.T) {
+	raw, err := sonnet.Marshal(struct {
+		ID Snowflake `json:"id"`
+	}{ID: 1055582516565782599})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(raw) != `{"id":"1055582516565782599"}` {
+		t.Errorf("expected snowflake to be encoded as string, got: %s", raw)
+	}
+
+	for _, payload := range []string{`{"id":"1055582516565782599"}`, `{"id":1055582516565782599}`} {
+		var res struct {
+			ID Snowflake `json:"id"`
+		}
+
+		if err := sonnet.Unmarshal([]byte(payload), &res); err != nil || res.ID != 1055582516565782599 {
+			t.Errorf("failed to decode %s (got: %d, err: %v)", payload, res.ID, err)
+		}
+	}
+
+	res := struct {
+		ID Snowflake `json:"id"`
+	}{ID: 5}
+	if err := sonnet.Unmarshal([]byte(`{"id":null}`), &res); err != nil || res.ID != 5 {
+		t.Errorf("expected null to leave snowflake unchanged (got: %d, err: %v)", res.ID, err)
+	}
+}
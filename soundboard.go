@@ -0,0 +1,29 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/soundboard#soundboard-sound-object
+type SoundboardSound struct {
+	Name      string    `json:"name"`
+	SoundID   Snowflake `json:"sound_id"`
+	Volume    float64   `json:"volume"`               // Volume of this sound, from 0 to 1.
+	EmojiID   Snowflake `json:"emoji_id,omitempty"`   // Id of this sound's custom emoji.
+	EmojiName string    `json:"emoji_name,omitempty"` // Unicode character of this sound's standard emoji.
+	GuildID   Snowflake `json:"guild_id,omitempty"`   // Not present on default sounds.
+	Available bool      `json:"available"`            // Whether this sound can be used, may be false due to loss of server boosts.
+	User      *User     `json:"user,omitempty"`       // User who created this sound.
+}
+
+// https://discord.com/developers/docs/resources/soundboard#create-guild-soundboard-sound-json-params
+//
+// Name & Sound are required when creating sound, Sound cannot be changed later (and is ignored when editing).
+// Leave other fields as <nil> to keep their current (or default) values.
+type SoundboardSoundParams struct {
+	Name      string     `json:"name,omitempty"`       // 2-32 characters.
+	Sound     string     `json:"sound,omitempty"`      // Data uri of mp3 or ogg sound, up to 512kb and 5.2s (see DataURI function).
+	Volume    *float64   `json:"volume,omitempty"`     // Volume of the sound, from 0 to 1. (default: 1)
+	EmojiID   *Snowflake `json:"emoji_id,omitempty"`   // Id of custom emoji for the sound.
+	EmojiName *string    `json:"emoji_name,omitempty"` // Unicode character of standard emoji for the sound.
+}
+
+type soundboardSoundList struct {
+	Items []SoundboardSound `json:"items"`
+}
@@ -0,0 +1,39 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/sticker#sticker-object-sticker-types
+type StickerType uint8
+
+const (
+	STANDARD_STICKER_TYPE StickerType = iota + 1 // Official sticker in a pack.
+	GUILD_STICKER_TYPE                           // Sticker uploaded to a guild for the guild's members.
+)
+
+// https://discord.com/developers/docs/resources/sticker#sticker-object-sticker-structure
+type Sticker struct {
+	ID          Snowflake         `json:"id"`
+	PackID      Snowflake         `json:"pack_id,omitempty"` // For standard stickers, id of the pack the sticker is from.
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Tags        string            `json:"tags"` // Autocomplete/suggestion tags for the sticker (max 200 characters).
+	Type        StickerType       `json:"type"`
+	FormatType  StickerFormatType `json:"format_type"`
+	Available   bool              `json:"available,omitempty"` // Whether this guild sticker can be used, may be false due to loss of server boosts.
+	GuildID     Snowflake         `json:"guild_id,omitempty"`
+	User        *User             `json:"user,omitempty"`       // User that uploaded the guild sticker.
+	SortValue   uint32            `json:"sort_value,omitempty"` // Standard sticker's sort order within its pack.
+}
+
+// https://discord.com/developers/docs/resources/sticker#sticker-pack-object-sticker-pack-structure
+type StickerPack struct {
+	ID             Snowflake  `json:"id"`
+	Stickers       []Sticker  `json:"stickers"`
+	Name           string     `json:"name"`
+	SKUID          *Snowflake `json:"sku_id,omitempty"`
+	CoverStickerID *Snowflake `json:"cover_sticker_id,omitempty"` // Id of a sticker in the pack which is shown as the pack's icon.
+	Description    string     `json:"description"`
+	BannerAssetID  *Snowflake `json:"banner_asset_id,omitempty"` // Id of the sticker pack's banner image.
+}
+
+type stickerPackList struct {
+	StickerPacks []StickerPack `json:"sticker_packs"`
+}
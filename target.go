@@ -16,6 +16,82 @@ const (
 	BASIC_NITRO_TYPE
 )
 
+// https://discord.com/developers/docs/resources/user#user-object-user-flags
+type UserFlag uint64
+
+const (
+	STAFF_USER_FLAG                    UserFlag = 1 << 0  // Discord Employee
+	PARTNER_USER_FLAG                  UserFlag = 1 << 1  // Partnered Server Owner
+	HYPESQUAD_USER_FLAG                UserFlag = 1 << 2  // HypeSquad Events Member
+	BUG_HUNTER_LEVEL_1_USER_FLAG       UserFlag = 1 << 3  // Bug Hunter Level 1
+	HYPESQUAD_ONLINE_HOUSE_1_USER_FLAG UserFlag = 1 << 6  // House Bravery Member
+	HYPESQUAD_ONLINE_HOUSE_2_USER_FLAG UserFlag = 1 << 7  // House Brilliance Member
+	HYPESQUAD_ONLINE_HOUSE_3_USER_FLAG UserFlag = 1 << 8  // House Balance Member
+	PREMIUM_EARLY_SUPPORTER_USER_FLAG  UserFlag = 1 << 9  // Early Nitro Supporter
+	TEAM_PSEUDO_USER_USER_FLAG         UserFlag = 1 << 10 // User is a team
+	BUG_HUNTER_LEVEL_2_USER_FLAG       UserFlag = 1 << 14 // Bug Hunter Level 2
+	VERIFIED_BOT_USER_FLAG             UserFlag = 1 << 16 // Verified Bot
+	VERIFIED_DEVELOPER_USER_FLAG       UserFlag = 1 << 17 // Early Verified Bot Developer
+	CERTIFIED_MODERATOR_USER_FLAG      UserFlag = 1 << 18 // Moderator Programs Alumni
+	BOT_HTTP_INTERACTIONS_USER_FLAG    UserFlag = 1 << 19 // Bot uses only HTTP interactions and is shown in the online member list
+	ACTIVE_DEVELOPER_USER_FLAG         UserFlag = 1 << 22 // User is an Active Developer
+)
+
+// All known user flags, in ascending order.
+var userFlags = []UserFlag{
+	STAFF_USER_FLAG,
+	PARTNER_USER_FLAG,
+	HYPESQUAD_USER_FLAG,
+	BUG_HUNTER_LEVEL_1_USER_FLAG,
+	HYPESQUAD_ONLINE_HOUSE_1_USER_FLAG,
+	HYPESQUAD_ONLINE_HOUSE_2_USER_FLAG,
+	HYPESQUAD_ONLINE_HOUSE_3_USER_FLAG,
+	PREMIUM_EARLY_SUPPORTER_USER_FLAG,
+	TEAM_PSEUDO_USER_USER_FLAG,
+	BUG_HUNTER_LEVEL_2_USER_FLAG,
+	VERIFIED_BOT_USER_FLAG,
+	VERIFIED_DEVELOPER_USER_FLAG,
+	CERTIFIED_MODERATOR_USER_FLAG,
+	BOT_HTTP_INTERACTIONS_USER_FLAG,
+	ACTIVE_DEVELOPER_USER_FLAG,
+}
+
+func (flag UserFlag) String() string {
+	switch flag {
+	case STAFF_USER_FLAG:
+		return "STAFF"
+	case PARTNER_USER_FLAG:
+		return "PARTNER"
+	case HYPESQUAD_USER_FLAG:
+		return "HYPESQUAD"
+	case BUG_HUNTER_LEVEL_1_USER_FLAG:
+		return "BUG_HUNTER_LEVEL_1"
+	case HYPESQUAD_ONLINE_HOUSE_1_USER_FLAG:
+		return "HYPESQUAD_ONLINE_HOUSE_1"
+	case HYPESQUAD_ONLINE_HOUSE_2_USER_FLAG:
+		return "HYPESQUAD_ONLINE_HOUSE_2"
+	case HYPESQUAD_ONLINE_HOUSE_3_USER_FLAG:
+		return "HYPESQUAD_ONLINE_HOUSE_3"
+	case PREMIUM_EARLY_SUPPORTER_USER_FLAG:
+		return "PREMIUM_EARLY_SUPPORTER"
+	case TEAM_PSEUDO_USER_USER_FLAG:
+		return "TEAM_PSEUDO_USER"
+	case BUG_HUNTER_LEVEL_2_USER_FLAG:
+		return "BUG_HUNTER_LEVEL_2"
+	case VERIFIED_BOT_USER_FLAG:
+		return "VERIFIED_BOT"
+	case VERIFIED_DEVELOPER_USER_FLAG:
+		return "VERIFIED_DEVELOPER"
+	case CERTIFIED_MODERATOR_USER_FLAG:
+		return "CERTIFIED_MODERATOR"
+	case BOT_HTTP_INTERACTIONS_USER_FLAG:
+		return "BOT_HTTP_INTERACTIONS"
+	case ACTIVE_DEVELOPER_USER_FLAG:
+		return "ACTIVE_DEVELOPER"
+	}
+	return "UNKNOWN"
+}
+
 // https://discord.com/developers/docs/resources/user#user-object-user-structure
 type User struct {
 	ID            Snowflake `json:"id"`
@@ -24,11 +100,34 @@ type User struct {
 	AvatarHash    string    `json:"avatar,omitempty"` // Hash code used to access user's profile. Call User.AvatarURL to get direct url.
 	Bot           bool      `json:"bot,omitempty"`
 	MFA           bool      `json:"mfa_enabled,omitempty"`
+	Verified      bool      `json:"verified,omitempty"`     // Whether the email on this account has been verified. Requires OAuth2 email scope.
+	Email         string    `json:"email,omitempty"`        // Requires OAuth2 email scope.
 	BannerHash    string    `json:"banner,omitempty"`       // Hash code used to access user's baner. Call User.BannerURL to get direct url.
 	AccentColor   uint32    `json:"accent_color,omitempty"` // User's banner color, encoded as an integer representation of hexadecimal color code.
 	Locale        string    `json:"locale,omitempty"`
 	PremiumType   NitroType `json:"premium_type,omitempty"`
-	PublicFlags   uint64    `json:"public_flags,omitempty"` // (Same as regular flags)
+	PublicFlags   uint64    `json:"public_flags,omitempty"` // (Same as regular flags) Use User.HasFlag or User.Flags to decode it.
+}
+
+// Checks whether user has given flag (badge) in public flags.
+func (user User) HasFlag(flag UserFlag) bool {
+	return user.PublicFlags&uint64(flag) == uint64(flag)
+}
+
+// Decodes user's public flags into list of known flags (badges). Unknown bits are skipped.
+func (user User) Flags() []UserFlag {
+	flags := make([]UserFlag, 0)
+	for _, flag := range userFlags {
+		if user.HasFlag(flag) {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// Whether user has any kind of Nitro subscription.
+func (user User) IsNitro() bool {
+	return user.PremiumType != NO_NITRO_TYPE
 }
 
 // Deprecated: Read more at https://discord.com/blog/usernames.
@@ -88,6 +187,19 @@ type Member struct {
 	GuildID                    Snowflake   `json:"-"`
 }
 
+// https://discord.com/developers/docs/resources/guild#modify-guild-member-json-params
+//
+// Leave fields as <nil> to keep their current values. Set Nickname to empty string to remove member's nickname.
+type MemberParams struct {
+	Nickname                   *string      `json:"nick,omitempty"`                         // Requires MANAGE_NICKNAMES_PERMISSION_FLAG.
+	Roles                      *[]Snowflake `json:"roles,omitempty"`                        // Replaces all member's roles. Requires MANAGE_ROLES_PERMISSION_FLAG.
+	Mute                       *bool        `json:"mute,omitempty"`                         // Requires MUTE_MEMBERS_PERMISSION_FLAG.
+	Deaf                       *bool        `json:"deaf,omitempty"`                         // Requires DEAFEN_MEMBERS_PERMISSION_FLAG.
+	ChannelID                  *Snowflake   `json:"channel_id,omitempty"`                   // Id of voice channel to move member to (if they are connected to voice). Requires MOVE_MEMBERS_PERMISSION_FLAG.
+	CommunicationDisabledUntil *time.Time   `json:"communication_disabled_until,omitempty"` // Timeout expiry (up to 28 days in the future). Requires MODERATE_MEMBERS_PERMISSION_FLAG.
+	Flags                      *uint64      `json:"flags,omitempty"`
+}
+
 // Returns a direct url to members's guild specific avatar. It'll return empty string if targeted member don't use custom avatar for that server.
 func (member Member) GuildAvatarURL() string {
 	if member.GuildAvatarHash == "" {
@@ -130,3 +242,13 @@ func (role Role) IconURL() string {
 
 	return DISCORD_CDN_URL + "/role-icons/" + role.ID.String() + "/" + role.IconHash + ".png"
 }
+
+// Whether member has role with provided id.
+func (member Member) HasRole(roleID Snowflake) bool {
+	for _, ID := range member.RoleIDs {
+		if ID == roleID {
+			return true
+		}
+	}
+	return false
+}
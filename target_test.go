@@ -26,6 +26,10 @@ func TestUser(t *testing.T) {
 		t.Error("failed to parse example user (json) object")
 	}
 
+	if !user.Verified || user.Email != "nelly@discord.com" || !user.IsNitro() {
+		t.Error("parsed user has invalid account details")
+	}
+
 	if user.ID != 80351110224678912 {
 		t.Error("parsed user has invalid ID")
 	}
@@ -97,3 +101,20 @@ func TestMember(t *testing.T) {
 		t.Error("parsed member joined at date is invalid")
 	}
 }
+
+func TestUserFlags(t *testing.T) {
+	user := User{PublicFlags: uint64(HYPESQUAD_ONLINE_HOUSE_1_USER_FLAG | ACTIVE_DEVELOPER_USER_FLAG | 1<<50)}
+
+	if !user.HasFlag(ACTIVE_DEVELOPER_USER_FLAG) || user.HasFlag(STAFF_USER_FLAG) {
+		t.Error("user has invalid flag check result")
+	}
+
+	flags := user.Flags()
+	if len(flags) != 2 || flags[0] != HYPESQUAD_ONLINE_HOUSE_1_USER_FLAG || flags[1] != ACTIVE_DEVELOPER_USER_FLAG {
+		t.Errorf("unexpected decoded flags: %v", flags)
+	}
+
+	if flags[1].String() != "ACTIVE_DEVELOPER" || UserFlag(1<<50).String() != "UNKNOWN" {
+		t.Error("user flag has invalid string representation")
+	}
+}
@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	gotesting "testing"
+
+	tempest "github.com/Amatsagu/Tempest"
+)
+
+// Lightweight alternative to DiscordTestServer for unit testing handlers that use REST methods (like Client.SendMessage or Client.FetchUser).
+// It doesn't start any server - pre-canned responses are returned straight from Rest's http transport.
+//
+//	mock := tempesttest.NewMockRest().ExpectRequest(http.MethodGet, "/users/1", []byte(`{"id": "1"}`), nil)
+//	client := tempest.NewClient(tempest.ClientOptions{Rest: mock.Rest})
+//	...
+//	mock.AssertAllExpectationsMet(t)
+type MockRest struct {
+	Rest *tempest.Rest // Rest bound to mock, pass it to tempest.ClientOptions.
+
+	mu           sync.Mutex
+	expectations []*mockExpectation
+	unexpected   []string
+}
+
+type mockExpectation struct {
+	method   string
+	route    string
+	response []byte
+	err      error
+	met      bool
+}
+
+func NewMockRest() *MockRest {
+	mock := &MockRest{}
+	mock.Rest = tempest.NewCustomRest("Bot test", &http.Client{Transport: mockTransport{mock: mock}})
+	mock.Rest.BaseURL = "https://discord.mock"
+	return mock
+}
+
+// Registers pre-canned response for request matching method & route (relative to API url, may contain query string).
+// Each expectation is used once, in order of registration. Use <nil> response for empty (204) reply.
+// When err is set, request fails with Discord like error response containing err's message.
+func (mock *MockRest) ExpectRequest(method string, route string, response []byte, err error) *MockRest {
+	mock.mu.Lock()
+	mock.expectations = append(mock.expectations, &mockExpectation{
+		method:   method,
+		route:    route,
+		response: response,
+		err:      err,
+	})
+	mock.mu.Unlock()
+	return mock
+}
+
+// Fails test if any registered expectation wasn't used or mock received request it didn't expect.
+func (mock *MockRest) AssertAllExpectationsMet(t gotesting.TB) {
+	t.Helper()
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	for _, exp := range mock.expectations {
+		if !exp.met {
+			t.Errorf("expected %s %s request but it was never made", exp.method, exp.route)
+		}
+	}
+
+	for _, request := range mock.unexpected {
+		t.Errorf("received unexpected %s request", request)
+	}
+}
+
+func (mock *MockRest) take(method string, route string, query string) *mockExpectation {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	for _, exp := range mock.expectations {
+		if !exp.met && exp.method == method && (exp.route == route || (query != "" && exp.route == route+"?"+query)) {
+			exp.met = true
+			return exp
+		}
+	}
+
+	mock.unexpected = append(mock.unexpected, method+" "+route)
+	return nil
+}
+
+type mockTransport struct {
+	mock *MockRest
+}
+
+func (mt mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	exp := mt.mock.take(req.Method, req.URL.Path, req.URL.RawQuery)
+
+	switch {
+	case exp == nil:
+		return mockResponse(req, http.StatusNotFound, []byte(`{"message": "Unknown route (no expectation registered in mock)", "code": 0}`)), nil
+	case exp.err != nil:
+		return mockResponse(req, http.StatusInternalServerError, []byte(exp.err.Error())), nil
+	case len(exp.response) == 0:
+		return mockResponse(req, http.StatusNoContent, nil), nil
+	}
+
+	return mockResponse(req, http.StatusOK, exp.response), nil
+}
+
+func mockResponse(req *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:     strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
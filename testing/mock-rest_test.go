@@ -0,0 +1,165 @@
+package testing_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	tempest "github.com/Amatsagu/Tempest"
+	tempesttest "github.com/Amatsagu/Tempest/testing"
+)
+
+func TestMockRest(t *testing.T) {
+	mock := tempesttest.NewMockRest().
+		ExpectRequest(http.MethodGet, "/users/1", []byte(`{"id": "1", "username": "Nelly"}`), nil).
+		ExpectRequest(http.MethodGet, "/users/2", nil, errors.New("unknown user"))
+
+	client := tempest.NewClient(tempest.ClientOptions{ApplicationID: tempesttest.TEST_APPLICATION_ID, Rest: mock.Rest})
+
+	user, err := client.FetchUser(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Username != "Nelly" {
+		t.Errorf("expected user from pre-canned response, got: %+v", user)
+	}
+
+	if _, err := client.FetchUser(2); err == nil || !strings.Contains(err.Error(), "unknown user") {
+		t.Errorf("expected pre-canned error, got: %v", err)
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestMockRestBearer(t *testing.T) {
+	mock := tempesttest.NewMockRest().ExpectRequest(http.MethodGet, "/users/@me/guilds", []byte(`[{"id": "1", "name": "Test", "owner": true, "permissions": "8"}]`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{Rest: mock.Rest})
+
+	guilds, err := client.FetchUserGuilds("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(guilds) != 1 || !guilds[0].Owner || guilds[0].PermissionFlags != tempest.ADMINISTRATOR_PERMISSION_FLAG {
+		t.Errorf("unexpected guilds: %+v", guilds)
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestMockRestQuery(t *testing.T) {
+	mock := tempesttest.NewMockRest().ExpectRequest(http.MethodGet, "/channels/10/thread-members?after=5&limit=50&with_member=true", []byte(`[{"id": "10", "user_id": "6", "join_timestamp": "2023-01-01T00:00:00Z", "flags": 0, "member": {"nick": "Nelly"}}]`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{Rest: mock.Rest})
+
+	members, err := client.FetchThreadMembers(10, true, 5, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 1 || members[0].UserID != 6 || members[0].Member == nil {
+		t.Errorf("unexpected thread members: %+v", members)
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestFilterMembersByRole(t *testing.T) {
+	page := &strings.Builder{}
+	page.WriteString("[")
+	for i := 1; i <= tempest.MAX_MEMBERS_PAGE_SIZE; i++ {
+		if i != 1 {
+			page.WriteString(",")
+		}
+
+		role := "3"
+		if i%2 == 0 {
+			role = "4"
+		}
+		fmt.Fprintf(page, `{"user":{"id":"%d"},"roles":["%s"]}`, i, role)
+	}
+	page.WriteString("]")
+
+	mock := tempesttest.NewMockRest().
+		ExpectRequest(http.MethodGet, "/guilds/1/members?limit=1000", []byte(page.String()), nil).
+		ExpectRequest(http.MethodGet, "/guilds/1/members?after=1000&limit=1000", []byte(`[{"user":{"id":"1001"},"roles":["4"]}]`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{Rest: mock.Rest})
+
+	members, err := client.FilterMembersByRole(1, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 501 || members[0].User.ID != 2 || members[500].User.ID != 1001 {
+		t.Errorf("unexpected filtered members (got %d)", len(members))
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestBanPaginator(t *testing.T) {
+	mock := tempesttest.NewMockRest().
+		ExpectRequest(http.MethodGet, "/guilds/1/bans?limit=2", []byte(`[{"user":{"id":"5"},"reason":"spam"},{"user":{"id":"8"},"reason":null}]`), nil).
+		ExpectRequest(http.MethodGet, "/guilds/1/bans?after=8&limit=2", []byte(`[{"user":{"id":"9"}}]`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{Rest: mock.Rest})
+
+	bans := client.GuildBans(1, 2)
+	var users []tempest.Snowflake
+	for bans.HasMore() {
+		page, err := bans.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ban := range page {
+			users = append(users, ban.User.ID)
+		}
+	}
+
+	if len(users) != 3 || users[0] != 5 || users[2] != 9 {
+		t.Errorf("unexpected banned users: %v", users)
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestBotHasPermissions(t *testing.T) {
+	mock := tempesttest.NewMockRest().
+		ExpectRequest(http.MethodGet, "/users/@me", []byte(`{"id":"9","bot":true}`), nil).
+		ExpectRequest(http.MethodGet, "/guilds/1", []byte(`{"id":"1","owner_id":"4","roles":[{"id":"1","permissions":"1024"},{"id":"2","permissions":"2048"}]}`), nil).
+		ExpectRequest(http.MethodGet, "/guilds/1/members/9", []byte(`{"user":{"id":"9"},"roles":["2"]}`), nil).
+		ExpectRequest(http.MethodGet, "/channels/7", []byte(`{"id":"7","type":11,"parent_id":"6"}`), nil).
+		ExpectRequest(http.MethodGet, "/channels/6", []byte(`{"id":"6","type":0,"permission_overwrites":[{"id":"2","type":0,"allow":"0","deny":"2048"}]}`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{ApplicationID: 8, Rest: mock.Rest}) // Older app, its bot user has different id.
+
+	allowed, err := client.BotHasPermissions(1, 7, tempest.VIEW_CHANNEL_PERMISSION_FLAG|tempest.SEND_MESSAGES_PERMISSION_FLAG)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allowed {
+		t.Error("expected parent channel overwrite to deny sending messages in thread")
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
+
+func TestBotHasPermissionsAsOwner(t *testing.T) {
+	mock := tempesttest.NewMockRest().
+		ExpectRequest(http.MethodGet, "/users/@me", []byte(`{"id":"9","bot":true}`), nil).
+		ExpectRequest(http.MethodGet, "/guilds/1", []byte(`{"id":"1","owner_id":"9","roles":[{"id":"1","permissions":"0"}]}`), nil)
+	client := tempest.NewClient(tempest.ClientOptions{ApplicationID: 9, Rest: mock.Rest})
+
+	allowed, err := client.BotHasPermissions(1, 7, tempest.MANAGE_GUILD_PERMISSION_FLAG)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !allowed {
+		t.Error("expected guild owner to have all permissions")
+	}
+
+	mock.AssertAllExpectationsMet(t)
+}
@@ -0,0 +1,216 @@
+// Package testing provides tools for testing Tempest based applications without access to Discord API.
+package testing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	tempest "github.com/Amatsagu/Tempest"
+	"github.com/sugawarayuuta/sonnet"
+)
+
+const TEST_APPLICATION_ID tempest.Snowflake = 1
+
+// Fake Discord server. It answers REST requests made by bound client with registered (expected) responses
+// and lets you fire signed interactions straight into client's handler.
+type DiscordTestServer struct {
+	Server *httptest.Server // Underlying http server that simulates Discord REST API.
+
+	client       *tempest.Client
+	privateKey   ed25519.PrivateKey
+	mu           sync.Mutex
+	expectations map[string]expectation
+	requests     []RecordedRequest
+	lastResponse *httptest.ResponseRecorder
+	lastID       tempest.Snowflake // Last id used for simulated interaction.
+}
+
+// Request that reached fake Discord REST API.
+type RecordedRequest struct {
+	Method string
+	Route  string // Route without API url prefix, example: "/users/@me".
+	Body   []byte
+}
+
+type expectation struct {
+	statusCode int
+	body       []byte
+}
+
+// Starts new fake Discord server and creates client bound to it. Remember to call DiscordTestServer.Close after finishing test.
+// Returned client is not running yet so you can still register commands, components & modals.
+func NewDiscordTestServer() (*DiscordTestServer, *tempest.Client) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		panic("failed to generate test signing keypair: " + err.Error())
+	}
+
+	ts := &DiscordTestServer{
+		privateKey:   privateKey,
+		expectations: make(map[string]expectation),
+	}
+	ts.Server = httptest.NewServer(http.HandlerFunc(ts.serveREST))
+
+	rest := tempest.NewCustomRest("Bot test", ts.Server.Client())
+	rest.BaseURL = ts.Server.URL
+
+	ts.client = tempest.NewClient(tempest.ClientOptions{
+		ApplicationID: TEST_APPLICATION_ID,
+		PublicKey:     hex.EncodeToString(publicKey),
+		Rest:          rest,
+	})
+
+	return ts, ts.client
+}
+
+// Registers response for matching REST request. Route is relative to API url (example: "/users/@me") and may contain query string.
+// Body can be any JSON serializable value, raw []byte or string. Use <nil> for empty body.
+func (ts *DiscordTestServer) Expect(method string, route string, statusCode int, body interface{}) {
+	var raw []byte
+	switch value := body.(type) {
+	case nil:
+	case []byte:
+		raw = value
+	case string:
+		raw = []byte(value)
+	default:
+		encoded, err := sonnet.Marshal(value)
+		if err != nil {
+			panic("failed to parse expected response body (make sure it's in JSON format)")
+		}
+		raw = encoded
+	}
+
+	ts.mu.Lock()
+	ts.expectations[method+" "+route] = expectation{statusCode: statusCode, body: raw}
+	ts.mu.Unlock()
+}
+
+// Signs payload with server's key and sends it to client's handler, the same way Discord does.
+// It'll return error if client rejected interaction (status code >= 400) or its handler panicked.
+func (ts *DiscordTestServer) SimulateInteraction(payload interface{}) (err error) {
+	var body []byte
+	switch value := payload.(type) {
+	case []byte:
+		body = value
+	case string:
+		body = []byte(value)
+	default:
+		body, err = sonnet.Marshal(value)
+		if err != nil {
+			return errors.New("failed to parse provided payload (make sure it's in JSON format)")
+		}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := ed25519.Sign(ts.privateKey, append([]byte(timestamp), body...))
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+
+	recorder := httptest.NewRecorder()
+	ts.mu.Lock()
+	ts.lastResponse = recorder
+	ts.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("client's handler panicked while processing interaction")
+		}
+	}()
+
+	ts.client.Hijack()(recorder, req)
+
+	if recorder.Code >= 400 {
+		return errors.New("client rejected interaction with status " + strconv.Itoa(recorder.Code) + " :: " + recorder.Body.String())
+	}
+
+	return nil
+}
+
+// Simulates user with given id clicking button with matching custom id (in DM channel). Returns interaction that was sent to client,
+// use DiscordTestServer.LastResponse to check what client's handler replied with.
+func (ts *DiscordTestServer) SimulateButtonClick(customID string, userID tempest.Snowflake) (*tempest.ComponentInteraction, error) {
+	ts.mu.Lock()
+	ts.lastID++
+	id := ts.lastID
+	ts.mu.Unlock()
+
+	itx := &tempest.ComponentInteraction{
+		ID:            id,
+		ApplicationID: TEST_APPLICATION_ID,
+		Type:          tempest.MESSAGE_COMPONENT_INTERACTION_TYPE,
+		Data: tempest.ComponentInteractionData{
+			CustomID: customID,
+			Type:     tempest.BUTTON_COMPONENT_TYPE,
+		},
+		User:    &tempest.User{ID: userID},
+		Token:   "token-" + id.String(),
+		Version: 1,
+	}
+
+	if err := ts.SimulateInteraction(itx); err != nil {
+		return nil, err
+	}
+
+	return itx, nil
+}
+
+// Returns response written by client's handler for last simulated interaction (<nil> if there was none).
+func (ts *DiscordTestServer) LastResponse() *httptest.ResponseRecorder {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.lastResponse
+}
+
+// Returns copy of all REST requests received so far, in order of arrival.
+func (ts *DiscordTestServer) Requests() []RecordedRequest {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	requests := make([]RecordedRequest, len(ts.requests))
+	copy(requests, ts.requests)
+	return requests
+}
+
+func (ts *DiscordTestServer) Close() {
+	ts.Server.Close()
+}
+
+func (ts *DiscordTestServer) serveREST(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	route := r.URL.Path
+
+	ts.mu.Lock()
+	ts.requests = append(ts.requests, RecordedRequest{Method: r.Method, Route: route, Body: body})
+
+	exp, available := ts.expectations[r.Method+" "+route+"?"+r.URL.RawQuery]
+	if !available {
+		exp, available = ts.expectations[r.Method+" "+route]
+	}
+	ts.mu.Unlock()
+
+	if !available {
+		http.Error(w, `{"message": "Unknown route (no expectation registered in test server)", "code": 0}`, http.StatusNotFound)
+		return
+	}
+
+	if exp.statusCode == http.StatusNoContent || len(exp.body) == 0 {
+		w.WriteHeader(exp.statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(exp.statusCode)
+	w.Write(exp.body)
+}
@@ -0,0 +1,280 @@
+package testing_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tempest "github.com/Amatsagu/Tempest"
+	tempesttest "github.com/Amatsagu/Tempest/testing"
+)
+
+func TestDiscordTestServer(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	var received bool
+	client.RegisterCommand(tempest.Command{
+		Name:        "ping",
+		Description: "Replies with pong.",
+		SlashCommandHandler: func(itx tempest.CommandInteraction) {
+			received = true
+			if err := itx.SendLinearReply("Pong!", false); err != nil {
+				t.Error(err)
+			}
+		},
+	})
+
+	server.Expect(http.MethodPost, "/interactions/10/token/callback", http.StatusNoContent, nil)
+
+	err := server.SimulateInteraction(tempest.CommandInteraction{
+		ID:            10,
+		ApplicationID: tempesttest.TEST_APPLICATION_ID,
+		Type:          tempest.APPLICATION_COMMAND_INTERACTION_TYPE,
+		Data:          tempest.CommandInteractionData{Name: "ping", Type: tempest.CHAT_INPUT_COMMAND_TYPE},
+		GuildID:       20,
+		Member:        &tempest.Member{User: &tempest.User{ID: 30}},
+		Token:         "token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !received {
+		t.Fatal("command handler didn't receive simulated interaction")
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Route != "/interactions/10/token/callback" {
+		t.Fatalf("expected single callback request, got: %+v", requests)
+	}
+}
+
+func TestDiscordTestServerRejectsUnknownRoute(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	if _, err := client.FetchUser(1); err == nil {
+		t.Error("expected error for request without registered expectation")
+	}
+}
+
+func TestDiscordTestServerExpect(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	server.Expect(http.MethodGet, "/users/1", http.StatusOK, tempest.User{ID: 1, Username: "Nelly"})
+
+	user, err := client.FetchUser(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Username != "Nelly" {
+		t.Errorf("expected user from registered expectation, got: %+v", user)
+	}
+}
+
+func TestSyncChangedCommands(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	ping := tempest.Command{Name: "ping", Description: "Replies with pong."}
+	client.RegisterCommand(ping)
+	client.RegisterCommand(tempest.Command{Name: "echo", Description: "Repeats message."})
+
+	server.Expect(http.MethodGet, "/applications/1/commands", http.StatusOK, `[
+		{"id": "100", "application_id": "1", "version": "5", "type": 1, "name": "ping", "description": "Replies with pong."},
+		{"id": "101", "application_id": "1", "version": "5", "type": 1, "name": "old", "description": "Removed command."}
+	]`)
+	server.Expect(http.MethodPost, "/applications/1/commands", http.StatusOK, `{}`)
+	server.Expect(http.MethodDelete, "/applications/1/commands/101", http.StatusNoContent, nil)
+
+	added, updated, deleted, err := client.SyncChangedCommands(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if added != 1 || updated != 0 || deleted != 1 {
+		t.Errorf("expected 1 added, 0 updated & 1 deleted commands, got: %d, %d, %d", added, updated, deleted)
+	}
+
+	if len(server.Requests()) != 3 {
+		t.Errorf("expected 3 requests, got: %+v", server.Requests())
+	}
+}
+
+func TestSyncChangedCommandsIgnoresDiscordDefaults(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	minValue := float64(1)
+	client.RegisterCommand(tempest.Command{
+		Name:        "roll",
+		Description: "Rolls a dice.",
+		Options: []tempest.CommandOption{
+			{
+				Type:        tempest.INTEGER_OPTION_TYPE,
+				Name:        "sides",
+				Description: "Number of sides.",
+				MinValue:    &minValue,
+				Choices: []tempest.Choice{
+					{Name: "six", Value: 6},
+				},
+			},
+		},
+	})
+
+	server.Expect(http.MethodGet, "/applications/1/commands", http.StatusOK, `[
+		{
+			"id": "100", "application_id": "1", "version": "5", "type": 1, "name": "roll", "description": "Rolls a dice.",
+			"name_localizations": null, "description_localizations": null, "default_member_permissions": null,
+			"dm_permission": true, "nsfw": false, "contexts": null, "integration_types": [0],
+			"options": [{
+				"type": 4, "name": "sides", "description": "Number of sides.", "min_value": 1,
+				"name_localizations": null, "description_localizations": null,
+				"choices": [{"name": "six", "value": 6, "name_localizations": null}]
+			}]
+		}
+	]`)
+
+	added, updated, deleted, err := client.SyncChangedCommands(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if added != 0 || updated != 0 || deleted != 0 {
+		t.Errorf("expected no changes, got: %d added, %d updated & %d deleted commands", added, updated, deleted)
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Errorf("expected only fetch request, got: %+v", server.Requests())
+	}
+}
+
+func TestSimulateButtonClick(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	var clickedBy tempest.Snowflake
+	client.RegisterComponent([]string{"confirm"}, func(itx tempest.ComponentInteraction) {
+		clickedBy = itx.User.ID
+		itx.AcknowledgeWithLinearMessage("Confirmed!", true)
+	})
+
+	itx, err := server.SimulateButtonClick("confirm", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if clickedBy != 30 || itx.Data.CustomID != "confirm" {
+		t.Errorf("component handler received unexpected interaction: %+v", itx)
+	}
+
+	if body := server.LastResponse().Body.String(); !strings.Contains(body, "Confirmed!") {
+		t.Errorf("expected handler's reply to be recorded, got: %s", body)
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	paginator := tempest.NewPaginator([]tempest.ResponseMessageData{{Content: "Page 1"}, {Content: "Page 2"}}, time.Second*2)
+	client.RegisterCommand(tempest.Command{
+		Name:        "pages",
+		Description: "Shows pages.",
+		SlashCommandHandler: func(itx tempest.CommandInteraction) {
+			if err := paginator.Send(itx); err != nil {
+				t.Error(err)
+			}
+		},
+	})
+
+	server.Expect(http.MethodPost, "/interactions/10/token/callback", http.StatusNoContent, nil)
+	server.Expect(http.MethodPatch, "/webhooks/1/token/messages/@original", http.StatusOK, `{}`)
+
+	if err := server.SimulateInteraction(pagesInteraction()); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEdits := func(count int, content string, disabledButtons int) {
+		t.Helper()
+
+		var requests []tempesttest.RecordedRequest
+		for deadline := time.Now().Add(time.Second * 3); time.Now().Before(deadline); time.Sleep(time.Millisecond * 10) {
+			if requests = server.Requests(); len(requests) == count+1 {
+				break
+			}
+		}
+
+		if len(requests) != count+1 {
+			t.Fatalf("expected %d message edits, got: %+v", count, requests)
+		}
+
+		body := string(requests[count].Body)
+		if requests[count].Method != http.MethodPatch || !strings.Contains(body, content) || strings.Count(body, `"disabled":true`) != disabledButtons {
+			t.Fatalf("expected edit showing %q with %d disabled buttons, got: %s", content, disabledButtons, body)
+		}
+	}
+
+	if body := string(server.Requests()[0].Body); !strings.Contains(body, "Page 1") || strings.Count(body, `"disabled":true`) != 1 {
+		t.Fatalf("expected first page with disabled previous button, got: %s", body)
+	}
+
+	if _, err := server.SimulateButtonClick("tempest-paginator-10-next", 30); err != nil {
+		t.Fatal(err)
+	}
+	expectEdits(1, "Page 2", 1)
+
+	if _, err := server.SimulateButtonClick("tempest-paginator-10-previous", 30); err != nil {
+		t.Fatal(err)
+	}
+	expectEdits(2, "Page 1", 1)
+
+	// After timeout paginator disables both buttons.
+	expectEdits(3, "Page 1", 2)
+}
+
+func TestPaginatorReleasesButtonsOnFailedReply(t *testing.T) {
+	server, client := tempesttest.NewDiscordTestServer()
+	defer server.Close()
+
+	paginator := tempest.NewPaginator([]tempest.ResponseMessageData{{Content: "Page 1"}, {Content: "Page 2"}}, time.Minute)
+	client.RegisterCommand(tempest.Command{
+		Name:        "pages",
+		Description: "Shows pages.",
+		SlashCommandHandler: func(itx tempest.CommandInteraction) {
+			if err := paginator.Send(itx); err == nil {
+				t.Error("expected paginator to return error from failed reply")
+			}
+		},
+	})
+
+	server.Expect(http.MethodPost, "/interactions/10/token/callback", http.StatusInternalServerError, `{"message": "Internal error", "code": 0}`)
+
+	if err := server.SimulateInteraction(pagesInteraction()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.SimulateButtonClick("tempest-paginator-10-next", 30); err != nil {
+		t.Fatal(err)
+	}
+
+	if body := server.LastResponse().Body.String(); body != "" {
+		t.Errorf("expected paginator's buttons to be released, but click was still acknowledged: %s", body)
+	}
+}
+
+func pagesInteraction() tempest.CommandInteraction {
+	return tempest.CommandInteraction{
+		ID:            10,
+		ApplicationID: tempesttest.TEST_APPLICATION_ID,
+		Type:          tempest.APPLICATION_COMMAND_INTERACTION_TYPE,
+		Data:          tempest.CommandInteractionData{Name: "pages", Type: tempest.CHAT_INPUT_COMMAND_TYPE},
+		GuildID:       20,
+		Member:        &tempest.Member{User: &tempest.User{ID: 30}},
+		Token:         "token",
+	}
+}
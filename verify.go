@@ -4,16 +4,44 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"encoding/hex"
-
+	"errors"
 	"io"
 	"net/http"
 )
 
-// Verifies incoming request if it's from Discord.
+// Verifies whether incoming request was sent (signed) by Discord. Use it when you handle Discord's requests outside of Tempest's client.
+// Public key is the same hex encoded key you'd pass to ClientOptions. It returns error only when provided public key is malformed.
+func VerifyRequest(r *http.Request, publicKey string) (bool, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return false, errors.New("failed to decode \"" + publicKey + "\" discord's public key (check if it's correct key)")
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return false, errors.New("discord's public key has invalid length (check if it's correct key)")
+	}
+
+	return verifyRequest(r, ed25519.PublicKey(key)), nil
+}
+
+// Verifies incoming request if it's from Discord. Request's body is restored afterwards so it can be read again.
 func verifyRequest(r *http.Request, key ed25519.PublicKey) bool {
-	var msg bytes.Buffer
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+
+	// Copy the original body back into the request after finishing.
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return verifySignature(r.Header, body, key)
+}
 
-	signature := r.Header.Get("X-Signature-Ed25519")
+// Verifies whether already read request body was signed by Discord.
+func verifySignature(header http.Header, body []byte, key ed25519.PublicKey) bool {
+	signature := header.Get("X-Signature-Ed25519")
 	if signature == "" {
 		return false
 	}
@@ -27,26 +55,13 @@ func verifyRequest(r *http.Request, key ed25519.PublicKey) bool {
 		return false
 	}
 
-	timestamp := r.Header.Get("X-Signature-Timestamp")
+	timestamp := header.Get("X-Signature-Timestamp")
 	if timestamp == "" {
 		return false
 	}
 
-	msg.WriteString(timestamp)
-
-	defer r.Body.Close()
-	var body bytes.Buffer
-
-	// Copy the original body back into the request after finishing.
-	defer func() {
-		r.Body = io.NopCloser(&body)
-	}()
-
-	// Copy body into buffers
-	_, err = io.Copy(&msg, io.TeeReader(r.Body, &body))
-	if err != nil {
-		return false
-	}
-
-	return ed25519.Verify(key, msg.Bytes(), sig)
+	msg := make([]byte, 0, len(timestamp)+len(body))
+	msg = append(msg, timestamp...)
+	msg = append(msg, body...)
+	return ed25519.Verify(key, msg, sig)
 }
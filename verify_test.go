@@ -66,3 +66,38 @@ func TestVerifyInteraction(t *testing.T) {
 		}
 	})
 }
+
+func TestVerifyRequest(t *testing.T) {
+	pubkey, privkey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Errorf("error generating signing keypair: %s", err)
+	}
+	timestamp := "1608597133"
+
+	t.Run("success", func(t *testing.T) {
+		body := "body"
+		request := httptest.NewRequest("POST", "http://localhost/interaction", strings.NewReader(body))
+		request.Header.Set("X-Signature-Timestamp", timestamp)
+		request.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privkey, []byte(timestamp+body))))
+
+		verified, err := VerifyRequest(request, hex.EncodeToString(pubkey))
+		if err != nil {
+			t.Error(err)
+		}
+
+		if !verified {
+			t.Error("failed to verify valid request")
+		}
+	})
+
+	t.Run("failure/malformed public key", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://localhost/interaction", strings.NewReader("body"))
+		if _, err := VerifyRequest(request, "not a hex key"); err == nil {
+			t.Error("expected error for malformed public key")
+		}
+
+		if _, err := VerifyRequest(request, "abcd"); err == nil {
+			t.Error("expected error for public key with invalid length")
+		}
+	})
+}
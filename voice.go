@@ -0,0 +1,10 @@
+package tempest
+
+// https://discord.com/developers/docs/resources/voice#voice-region-object-voice-region-structure
+type VoiceRegion struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Optimal    bool   `json:"optimal"`    // True for a single server that is closest to the current user's client.
+	Deprecated bool   `json:"deprecated"` // Whether this is a deprecated voice region (avoid switching to these).
+	Custom     bool   `json:"custom"`     // Whether this is a custom voice region (used for events/etc).
+}